@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -24,53 +26,46 @@ var authRegisterCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reader := bufio.NewReader(os.Stdin)
 
-		fmt.Print("Name: ")
-		name, _ := reader.ReadString('\n')
-		name = strings.TrimSpace(name)
+		name, err := prompt(cmd.Context(), reader, "Name: ")
+		if err != nil {
+			return err
+		}
 
-		fmt.Print("Email: ")
-		email, _ := reader.ReadString('\n')
-		email = strings.TrimSpace(email)
+		email, err := prompt(cmd.Context(), reader, "Email: ")
+		if err != nil {
+			return err
+		}
 
-		fmt.Print("Description (optional): ")
-		desc, _ := reader.ReadString('\n')
-		desc = strings.TrimSpace(desc)
+		desc, err := prompt(cmd.Context(), reader, "Description (optional): ")
+		if err != nil {
+			return err
+		}
 
-		fmt.Print("Scope [read/full] (default: read): ")
-		scope, _ := reader.ReadString('\n')
-		scope = strings.TrimSpace(scope)
+		scope, err := prompt(cmd.Context(), reader, "Scope [read/full] (default: read): ")
+		if err != nil {
+			return err
+		}
 		if scope == "" {
 			scope = "read"
 		}
 
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, "")
-
-		payload := map[string]string{
-			"name":        name,
-			"email":       email,
-			"description": desc,
-			"scope":       scope,
-		}
-
-		var resp struct {
-			AgentID           string   `json:"agent_id"`
-			APIKey            string   `json:"api_key"`
-			Name              string   `json:"name"`
-			Tier              string   `json:"tier"`
-			RateLimitPerMin   int      `json:"rate_limit_per_minute"`
-			EmailVerified     bool     `json:"email_verified"`
-			Capabilities      []string `json:"capabilities"`
-			Message           string   `json:"message"`
+		c, err := newClient("")
+		if err != nil {
+			return err
 		}
 
-		raw, err := c.Post("/api/v1/auth/register", payload, &resp)
+		resp, res, err := c.Register(cmd.Context(), fti.RegisterRequest{
+			Name:        name,
+			Email:       email,
+			Description: desc,
+			Scope:       scope,
+		})
 		if err != nil {
 			return err
 		}
 
 		if jsonOut {
-			internal.PrintJSON(raw)
+			printJSON(res)
 			return nil
 		}
 
@@ -90,28 +85,74 @@ var authRegisterCmd = &cobra.Command{
 
 // ── auth login ───────────────────────────────────────────────────────────────
 
+var loginStore string
+
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
-	Short: "Save an API key to ~/.fti/config.toml",
+	Short: "Save an API key to ~/.fti/config.toml or a secret store",
+	Long: `Save an API key to ~/.fti/config.toml. With --profile (or FTI_PROFILE or
+default_profile) the key is saved in that profile, which is created if
+needed.
+
+With --store keyring or --store file (or api_key_store in the config), the
+key goes to the OS keyring or the passphrase-encrypted ~/.fti/secrets.enc
+instead, and config.toml only records where it is.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Paste your API key (ti_live_...): ")
-		key, _ := reader.ReadString('\n')
-		key = strings.TrimSpace(key)
+		key, err := prompt(cmd.Context(), reader, "Paste your API key (ti_live_...): ")
+		if err != nil {
+			return err
+		}
 		if key == "" {
 			return fmt.Errorf("no API key provided")
 		}
 
-		cfg, err := internal.LoadConfig()
+		cfg, err := internal.ReadConfigFile()
 		if err != nil {
 			return err
 		}
-		cfg.APIKey = key
-		if err := internal.SaveConfig(cfg); err != nil {
+		name, _ := internal.ActiveProfile(cfg)
+		effective, _ := internal.LoadConfig()
+		store := loginStore
+		if store == "" {
+			store = effective.APIKeyStore
+		}
+
+		where, err := internal.ConfigPath()
+		if err != nil {
+			return err
+		}
+		if store != "" {
+			ks, err := internal.OpenKeyStore(store)
+			if err != nil {
+				return err
+			}
+			if err := ks.Set(internal.KeyAccount(name), key); err != nil {
+				return err
+			}
+			where = map[string]string{internal.StoreKeyring: "the OS keyring", internal.StoreFile: filepath.Join(filepath.Dir(where), "secrets.enc")}[store]
+		}
+		err = internal.UpdateConfig(func(cfg *internal.Config) error {
+			if store == "" {
+				return internal.SetConfigValue(cfg, targetKey(*cfg, "api_key"), key)
+			}
+			if err := internal.SetConfigValue(cfg, targetKey(*cfg, "api_key_store"), store); err != nil {
+				return err
+			}
+			return internal.UnsetConfigValue(cfg, targetKey(*cfg, "api_key"))
+		})
+		if err != nil {
 			return err
 		}
 
-		internal.Green.Println("API key saved to ~/.fti/config.toml")
+		if name == "" {
+			internal.Green.Printf("API key saved to %s\n", where)
+		} else {
+			internal.Green.Printf("API key saved to %s for profile %q\n", where, name)
+		}
+		if effective.APIKeyCmd != "" {
+			fmt.Fprintln(os.Stderr, internal.Yellow.Sprint("note: api_key_cmd is configured and takes precedence over the saved key"))
+		}
 		return nil
 	},
 }
@@ -127,30 +168,24 @@ var authMeCmd = &cobra.Command{
 			return err
 		}
 		if key == "" {
-			return fmt.Errorf("no API key found — run: fti auth login")
+			return internal.ErrNoAPIKey
 		}
 
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, key)
-
-		var resp struct {
-			AgentID         string   `json:"agent_id"`
-			Name            string   `json:"name"`
-			Description     string   `json:"description"`
-			Tier            string   `json:"tier"`
-			Capabilities    []string `json:"capabilities"`
-			RateLimitPerMin int      `json:"rate_limit_per_minute"`
-			TotalRequests   int      `json:"total_requests"`
-			CreatedAt       string   `json:"created_at"`
+		c, err := newClient(key)
+		if err != nil {
+			return err
 		}
 
-		raw, err := c.Get("/api/v1/auth/me", nil, &resp)
+		resp, res, err := c.Me(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if l := c.core.Limiter; l != nil {
+			l.SetLimit(resp.RateLimitPerMin) //nolint:errcheck
+		}
 
 		if jsonOut {
-			internal.PrintJSON(raw)
+			printJSON(res)
 			return nil
 		}
 
@@ -170,6 +205,8 @@ var authMeCmd = &cobra.Command{
 }
 
 func init() {
+	authLoginCmd.Flags().StringVar(&loginStore, "store", "", "Keep the key in \"keyring\" (OS keyring) or \"file\" (encrypted ~/.fti/secrets.enc)")
+
 	authCmd.AddCommand(authRegisterCmd)
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authMeCmd)
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+)
+
+func TestAuthLoginProfile(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_API_KEY", "")
+	t.Setenv("FTI_PROFILE", "")
+	writeConfig(t, `api_key = "ti_live_top"`)
+
+	in := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(in, []byte("ti_live_staging\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	saved := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = saved }()
+
+	if _, err := runFTI(t, "auth", "login", "--profile", "staging"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := internal.ReadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Profiles["staging"].APIKey; got != "ti_live_staging" {
+		t.Errorf("[profiles.staging] api_key = %q, want the pasted key", got)
+	}
+	if cfg.APIKey != "ti_live_top" {
+		t.Errorf("top-level api_key = %q, want it untouched", cfg.APIKey)
+	}
+}
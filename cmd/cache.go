@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clear the local response cache",
+}
+
+// ── cache stats ──────────────────────────────────────────────────────────────
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache size and freshness",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := internal.NewCache()
+		if err != nil {
+			return err
+		}
+		st, err := cache.Stats()
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			raw, err := json.Marshal(map[string]interface{}{
+				"dir":     cache.Dir,
+				"entries": st.Entries,
+				"expired": st.Expired,
+				"bytes":   st.Bytes,
+				"oldest":  st.Oldest,
+				"newest":  st.Newest,
+			})
+			if err != nil {
+				return err
+			}
+			internal.PrintJSON(raw)
+			return nil
+		}
+
+		internal.Bold.Printf("\n%s\n", cache.Dir)
+		fmt.Printf("  Entries:   %d (%d expired)\n", st.Entries, st.Expired)
+		fmt.Printf("  Size:      %s\n", formatBytes(st.Bytes))
+		if st.Entries > 0 {
+			fmt.Printf("  Oldest:    %s\n", internal.Dim.Sprint(st.Oldest.Format(time.RFC3339)))
+			fmt.Printf("  Newest:    %s\n", internal.Dim.Sprint(st.Newest.Format(time.RFC3339)))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// ── cache clear ──────────────────────────────────────────────────────────────
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached responses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := internal.NewCache()
+		if err != nil {
+			return err
+		}
+		n, err := cache.Clear()
+		if err != nil {
+			return err
+		}
+		internal.Green.Printf("Removed %d cached response(s)\n", n)
+		return nil
+	},
+}
+
+// ── cache path ───────────────────────────────────────────────────────────────
+
+var cachePathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the cache directory",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := internal.NewCache()
+		if err != nil {
+			return err
+		}
+		fmt.Println(cache.Dir)
+		return nil
+	},
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePathCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
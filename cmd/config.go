@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configShowOrigin bool
+	configReveal     bool
+)
+
+// settingEnv maps config keys to the environment variables that override them.
+var settingEnv = map[string]string{
+	"api_key":  "FTI_API_KEY",
+	"api_url":  "FTI_API_URL",
+	"api_urls": "FTI_API_URL",
+}
+
+// settingDefaults holds defaults for config keys without a global flag;
+// the others default to their flag's default.
+var settingDefaults = map[string]string{
+	"api_url":           fti.DefaultBaseURL,
+	"endpoint_cooldown": internal.DefaultEndpointCooldown.String(),
+}
+
+// resolveSetting returns the effective value of a config key and where it
+// came from: flag, env, defaults (a [defaults] section), project, profile,
+// file or default.
+func resolveSetting(cfg internal.Config, key string) setting {
+	f := rootCmd.PersistentFlags().Lookup(strings.ReplaceAll(key, "_", "-"))
+	origin := ""
+	if f != nil {
+		origin = flagOrigin(f.Name)
+	}
+	if origin == "flag" {
+		return setting{f.Value.String(), origin}
+	}
+	if env := settingEnv[key]; env != "" && os.Getenv(env) != "" {
+		return setting{os.Getenv(env), "env"}
+	}
+	if origin == "defaults" {
+		return setting{f.Value.String(), origin}
+	}
+	if v, ok, _ := internal.ConfigValue(cfg, key); ok {
+		_, fromProfile, _ := internal.ConfigValue(cfg, "profiles."+cfg.Profile+"."+key)
+		return setting{v, configOrigin(cfg, key, fromProfile)}
+	}
+	if v, ok := settingDefaults[key]; ok {
+		return setting{v, "default"}
+	}
+	if f != nil {
+		return setting{f.DefValue, "default"}
+	}
+	return setting{"", "default"}
+}
+
+// configOrigin names where a config setting came from: the project's
+// .fti.toml, the active profile when it sets the value, otherwise the config
+// file itself.
+func configOrigin(cfg internal.Config, key string, fromProfile bool) string {
+	if cfg.FromProject(key) {
+		return "project"
+	}
+	if cfg.Profile != "" && fromProfile {
+		return "profile " + cfg.Profile
+	}
+	return "file"
+}
+
+// displayValue masks secrets unless --reveal is given.
+func displayValue(key, value string) string {
+	if value != "" && internal.IsSecretKey(key) && !configReveal {
+		return internal.MaskSecret(value)
+	}
+	return value
+}
+
+// targetKey directs an unqualified profile key (e.g. api_key) into the
+// active profile, like auth login does.
+func targetKey(cfg internal.Config, key string) string {
+	name, _ := internal.ActiveProfile(cfg)
+	if name == "" || strings.Contains(key, ".") {
+		return key
+	}
+	for _, k := range internal.ProfileKeys() {
+		if k == key {
+			return "profiles." + name + "." + key
+		}
+	}
+	return key
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and edit ~/.fti/config.toml",
+	Long: `Inspect and edit ~/.fti/config.toml.
+
+Keys are the names used in the file (see: fti config list). Profile
+settings are addressed as profiles.<name>.<key>; with a profile active
+(--profile, FTI_PROFILE or default_profile), set and unset write
+api_key, api_url and api_urls into that profile.`,
+}
+
+// ── config path ──────────────────────────────────────────────────────────────
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the location of the config file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := internal.ConfigPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+// ── config get ───────────────────────────────────────────────────────────────
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			return err
+		}
+
+		var s setting
+		if strings.HasPrefix(key, "profiles.") {
+			v, _, err := internal.ConfigValue(cfg, key)
+			if err != nil {
+				return err
+			}
+			s = setting{v, "file"}
+		} else {
+			if _, _, err := internal.ConfigValue(cfg, key); err != nil {
+				return err
+			}
+			s = resolveSetting(cfg, key)
+		}
+		s.Value = displayValue(key, s.Value)
+
+		if jsonOut {
+			raw, err := json.Marshal(map[string]interface{}{"key": key, "value": s.Value, "origin": s.Origin})
+			if err != nil {
+				return err
+			}
+			internal.PrintJSON(raw)
+			return nil
+		}
+		if configShowOrigin {
+			fmt.Printf("%s\t%s\n", s.Origin, s.Value)
+		} else {
+			fmt.Println(s.Value)
+		}
+		return nil
+	},
+}
+
+// ── config list ──────────────────────────────────────────────────────────────
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting with its effective value",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			return err
+		}
+
+		settings := map[string]setting{}
+		for _, key := range internal.ConfigKeys() {
+			s := resolveSetting(cfg, key)
+			s.Value = displayValue(key, s.Value)
+			settings[key] = s
+		}
+		for name := range cfg.Profiles {
+			for _, k := range internal.ProfileKeys() {
+				key := "profiles." + name + "." + k
+				if v, ok, _ := internal.ConfigValue(cfg, key); ok {
+					settings[key] = setting{displayValue(key, v), "file"}
+				}
+			}
+		}
+
+		if jsonOut {
+			raw, err := json.Marshal(settings)
+			if err != nil {
+				return err
+			}
+			internal.PrintJSON(raw)
+			return nil
+		}
+
+		keys := make([]string, 0, len(settings))
+		for k := range settings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		t := internal.NewTable("KEY", "VALUE")
+		if configShowOrigin {
+			t = internal.NewTable("KEY", "VALUE", "ORIGIN")
+		}
+		t.Header()
+		for _, k := range keys {
+			s := settings[k]
+			v := s.Value
+			if v == "" {
+				v = internal.Dim.Sprint("—")
+			}
+			if configShowOrigin {
+				t.Row(k, v, internal.Dim.Sprint(s.Origin))
+			} else {
+				t.Row(k, v)
+			}
+		}
+		t.Flush()
+		return nil
+	},
+}
+
+// ── config set / unset ───────────────────────────────────────────────────────
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Save a setting to the config file",
+	Long: `Save a setting to the config file. The value is checked before it is
+written: durations look like 30s or 2m, sizes like 32MB, lists (api_urls)
+are comma-separated.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var key, stored string
+		err := internal.UpdateConfig(func(cfg *internal.Config) error {
+			key = targetKey(*cfg, args[0])
+			if err := internal.SetConfigValue(cfg, key, args[1]); err != nil {
+				return err
+			}
+			stored, _, _ = internal.ConfigValue(*cfg, key)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !jsonOut {
+			internal.Green.Printf("%s = %s\n", key, displayValue(key, stored))
+		}
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var key string
+		err := internal.UpdateConfig(func(cfg *internal.Config) error {
+			key = targetKey(*cfg, args[0])
+			return internal.UnsetConfigValue(cfg, key)
+		})
+		if err != nil {
+			return err
+		}
+		if !jsonOut {
+			internal.Green.Printf("%s unset\n", key)
+		}
+		return nil
+	},
+}
+
+// ── config trust / untrust ───────────────────────────────────────────────────
+
+var configTrustCmd = &cobra.Command{
+	Use:   "trust [dir]",
+	Short: "Let a project's .fti.toml choose the API URL",
+	Long: `Add a project directory to trusted_projects, so the api_url or api_urls
+in its .fti.toml are used. Requests carry your API key, so only trust
+projects whose API server you trust. Without an argument, the directory of
+the nearest .fti.toml is trusted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := projectDirArg(args)
+		if err != nil {
+			return err
+		}
+		err = internal.UpdateConfig(func(cfg *internal.Config) error {
+			if !cfg.TrustsProject(dir) {
+				cfg.TrustedProjects = append(cfg.TrustedProjects, dir)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !jsonOut {
+			internal.Green.Printf("Trusted %s\n", dir)
+		}
+		return nil
+	},
+}
+
+var configUntrustCmd = &cobra.Command{
+	Use:   "untrust [dir]",
+	Short: "Stop using a project's API URL",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := projectDirArg(args)
+		if err != nil {
+			return err
+		}
+		err = internal.UpdateConfig(func(cfg *internal.Config) error {
+			kept := cfg.TrustedProjects[:0]
+			for _, d := range cfg.TrustedProjects {
+				if internal.CanonicalDir(d) != dir {
+					kept = append(kept, d)
+				}
+			}
+			cfg.TrustedProjects = kept
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !jsonOut {
+			internal.Green.Printf("No longer trusting %s\n", dir)
+		}
+		return nil
+	},
+}
+
+// projectDirArg returns the project directory named in args, or the one
+// holding the nearest .fti.toml.
+func projectDirArg(args []string) (string, error) {
+	if len(args) == 1 {
+		return internal.CanonicalDir(args[0]), nil
+	}
+	path, err := internal.ProjectConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", &internal.UsageError{Err: fmt.Errorf("no %s found here or in a parent directory", internal.ProjectConfigName)}
+	}
+	return internal.CanonicalDir(filepath.Dir(path)), nil
+}
+
+// ── config edit ──────────────────────────────────────────────────────────────
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in $VISUAL or $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := internal.ConfigPath()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("creating config dir: %w", err)
+		}
+
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		// The editor may carry arguments, e.g. "code --wait".
+		parts := strings.Fields(editor)
+		ed := exec.CommandContext(cmd.Context(), parts[0], append(parts[1:], path)...)
+		ed.Stdin, ed.Stdout, ed.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := ed.Run(); err != nil {
+			return fmt.Errorf("running %s: %w", editor, err)
+		}
+
+		unknown, err := internal.CheckConfigFile()
+		if err != nil {
+			return fmt.Errorf("%w; run fti config edit again to fix it", err)
+		}
+		for _, k := range unknown {
+			fmt.Fprintf(os.Stderr, "%s: unknown config key %q is ignored\n", internal.Yellow.Sprint("warning"), k)
+		}
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{configGetCmd, configListCmd} {
+		c.Flags().BoolVar(&configShowOrigin, "show-origin", false, "Show where each value came from (flag, env, defaults, project, profile, file or default)")
+		c.Flags().BoolVar(&configReveal, "reveal", false, "Show secrets such as api_key in full")
+	}
+
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configTrustCmd)
+	configCmd.AddCommand(configUntrustCmd)
+	rootCmd.AddCommand(configCmd)
+}
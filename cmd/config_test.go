@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+)
+
+// configEnv points fti at an empty config dir with nothing set in the
+// environment.
+func configEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_API_KEY", "")
+	t.Setenv("FTI_API_URL", "")
+	t.Setenv("FTI_PROFILE", "")
+}
+
+func TestConfigSetRejects(t *testing.T) {
+	configEnv(t)
+
+	for _, args := range [][]string{
+		{"timeout", "soon"},
+		{"retries", "-1"},
+		{"max_response_size", "huge"},
+		{"nosuch", "1"},
+		{"profiles.staging.nosuch", "1"},
+	} {
+		_, err := runFTI(t, append([]string{"config", "set"}, args...)...)
+		if code, kind := internal.Classify(err); code != internal.ExitUsage {
+			t.Errorf("config set %s: exit code %d (%s), want %d: %v", strings.Join(args, " "), code, kind, internal.ExitUsage, err)
+		}
+	}
+	cfg, err := internal.ReadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Timeout != "" || cfg.Retries != nil || cfg.MaxResponseSize != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("rejected values were saved: %+v", cfg)
+	}
+
+	if _, err := runFTI(t, "config", "set", "timeout", "5s"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := runFTI(t, "config", "get", "timeout"); strings.TrimSpace(out) != "5s" {
+		t.Errorf("config get timeout = %q after set", out)
+	}
+}
+
+func TestConfigMasksSecrets(t *testing.T) {
+	configEnv(t)
+	const key = "ti_live_0123456789abcdef"
+	writeConfig(t, `api_key = "`+key+`"`)
+
+	for _, args := range [][]string{{"get", "api_key"}, {"list"}} {
+		out, err := runFTI(t, append([]string{"config"}, args...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(out, key) || !strings.Contains(out, "ti_live_…REDACTED") {
+			t.Errorf("config %s shows the key unmasked:\n%s", args[0], out)
+		}
+
+		out, err = runFTI(t, append(append([]string{"config"}, args...), "--reveal")...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, key) {
+			t.Errorf("config %s --reveal lacks the key:\n%s", args[0], out)
+		}
+	}
+}
+
+func TestConfigShowOrigin(t *testing.T) {
+	configEnv(t)
+	writeConfig(t, `
+timeout = "5s"
+
+[defaults]
+retries = 2
+
+[profiles.staging]
+api_url = "https://staging.example"
+`)
+	t.Setenv("FTI_API_KEY", "ti_live_env")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"timeout"}, "file\t5s"},
+		{[]string{"timeout", "--timeout", "2s"}, "flag\t2s"},
+		{[]string{"api_key"}, "env\tti_live_…REDACTED"},
+		{[]string{"retries"}, "defaults\t2"},
+		{[]string{"strict"}, "default\tfalse"},
+		{[]string{"api_url", "--profile", "staging"}, "profile staging\thttps://staging.example"},
+	}
+	for _, tt := range tests {
+		out, err := runFTI(t, append([]string{"config", "get", "--show-origin"}, tt.args...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(out); got != tt.want {
+			t.Errorf("config get --show-origin %s = %q, want %q", strings.Join(tt.args, " "), got, tt.want)
+		}
+	}
+}
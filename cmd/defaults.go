@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configDefaults holds the flag defaults from the config's [defaults]
+// sections, by the command whose section set them.
+var configDefaults = map[*cobra.Command]map[*pflag.Flag]string{}
+
+// configApplied records the flags applyConfigDefaults set for the command
+// that runs.
+var configApplied = map[*pflag.Flag]bool{}
+
+// loadConfigDefaults reads the [defaults] sections of the config before
+// the command line is parsed, so help shows the defaults marked "(from
+// config)". The values themselves are only applied to the command that
+// runs (see applyConfigDefaults), since several commands may share a flag
+// variable. Mistakes in the sections are warned about rather than failing,
+// so they can't stop fti config edit from fixing them.
+func loadConfigDefaults() {
+	configApplied = map[*pflag.Flag]bool{}
+	defaults, err := internal.FlagDefaults()
+	if err != nil || len(defaults) == 0 {
+		return // a broken config is reported when the command loads it
+	}
+	loadDefaults(rootCmd, defaults, "defaults")
+}
+
+// loadDefaults records the defaults in table, the section named section,
+// for cmd's own flags. Nested tables apply to subcommands.
+func loadDefaults(cmd *cobra.Command, table map[string]interface{}, section string) {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if sub, ok := table[key].(map[string]interface{}); ok {
+			child := subcommand(cmd, key)
+			if child == nil {
+				warnDefaults(section, "%q is not a subcommand of %q", key, cmd.CommandPath())
+				continue
+			}
+			loadDefaults(child, sub, section+"."+key)
+			continue
+		}
+
+		name := strings.ReplaceAll(key, "_", "-")
+		f := cmd.LocalFlags().Lookup(name)
+		if f == nil {
+			warnDefaults(section, "%q has no flag --%s", cmd.CommandPath(), name)
+			continue
+		}
+		// Parse the value to check and normalise it, then put the built-in
+		// default back until the command runs.
+		builtin := f.DefValue
+		if err := f.Value.Set(defaultText(table[key])); err != nil {
+			warnDefaults(section, "invalid value for --%s: %v", name, err)
+			f.Value.Set(builtin) //nolint:errcheck
+			continue
+		}
+		value := f.Value.String()
+		f.Value.Set(builtin) //nolint:errcheck
+
+		if configDefaults[cmd] == nil {
+			configDefaults[cmd] = map[*pflag.Flag]string{}
+		}
+		configDefaults[cmd][f] = value
+		f.DefValue = value
+		f.Usage += " (from config)"
+	}
+}
+
+// applyConfigDefaults sets the config defaults of cmd and its parents on
+// the flags of cmd that were not given on the command line.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		for f, value := range configDefaults[c] {
+			if cmd.Flags().Lookup(f.Name) != f || f.Changed {
+				continue // another command's local flag, or given explicitly
+			}
+			if err := f.Value.Set(value); err != nil {
+				return err
+			}
+			configApplied[f] = true
+		}
+	}
+	return nil
+}
+
+// flagOrigin reports where the global flag name got its value: "flag" if
+// given on the command line, "defaults" if set from a [defaults] section,
+// or "" if it still has its built-in default. Either of the first two
+// takes precedence over the config key of the same name.
+func flagOrigin(name string) string {
+	f := rootCmd.PersistentFlags().Lookup(name)
+	switch {
+	case f == nil:
+		return ""
+	case f.Changed:
+		return "flag"
+	case configApplied[f]:
+		return "defaults"
+	}
+	return ""
+}
+
+// subcommand returns cmd's subcommand called name, or nil.
+func subcommand(cmd *cobra.Command, name string) *cobra.Command {
+	for _, c := range cmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// defaultText renders a TOML value as flag text; arrays are comma-separated.
+func defaultText(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, it := range v {
+			items[i] = defaultText(it)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+func warnDefaults(section, format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "%s: ignoring [%s]: %s\n", internal.Yellow.Sprint("warning"), section, fmt.Sprintf(format, args...))
+}
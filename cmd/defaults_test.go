@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+)
+
+func writeConfig(t *testing.T, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(os.Getenv("FTI_HOME"), "config.toml"), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigDefaultsStayWithTheirCommand(t *testing.T) {
+	api := newMockAPI(t, &mockapi.Server{})
+	writeConfig(t, `
+[defaults.signals.active]
+token = "CHZ"
+min-confidence = 0.8
+`)
+
+	tests := []struct {
+		args []string
+		want string // query the request must carry
+		not  string // query it must not carry
+	}{
+		{[]string{"signals", "history", "--no-cache"}, "/api/v1/signals/history", "token=CHZ"},
+		{[]string{"signals", "active", "--no-cache"}, "token=CHZ", ""},
+		{[]string{"signals", "active", "--no-cache", "--token", "PSG"}, "token=PSG", "token=CHZ"},
+		{[]string{"signals", "history", "--no-cache"}, "/api/v1/signals/history", "token=CHZ"},
+	}
+	for _, tt := range tests {
+		before := len(api.Requests())
+		if _, err := runFTI(t, tt.args...); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		reqs := api.Requests()[before:]
+		var got string
+		for _, r := range reqs {
+			if strings.HasPrefix(r, "/api/v1/signals/") {
+				got = r
+			}
+		}
+		if !strings.Contains(got, tt.want) || tt.not != "" && strings.Contains(got, tt.not) {
+			t.Errorf("%v requested %q, want %q without %q", tt.args, got, tt.want, tt.not)
+		}
+	}
+}
+
+func TestConfigDefaultsInHelp(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+	writeConfig(t, "[defaults.whales]\nhours = 6\n")
+
+	out, err := runFTI(t, "whales", "--help")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "(from config) (default 6)") {
+		t.Errorf("help does not mark the config default:\n%s", out)
+	}
+	if whalesHours != 24 {
+		t.Errorf("help changed --hours to %d", whalesHours)
+	}
+}
+
+func TestGlobalConfigDefaults(t *testing.T) {
+	api := newMockAPI(t, &mockapi.Server{}, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+			h.ServeHTTP(w, r)
+		})
+	})
+	writeConfig(t, `
+timeout = "30s"
+
+[defaults]
+timeout = "10ms"
+retries = 0
+`)
+
+	_, err := runFTI(t, "tokens", "list", "--no-cache")
+	if code, kind := internal.Classify(err); code != internal.ExitNetwork {
+		t.Fatalf("exit code = %d (%s), want a timeout from [defaults]: %v", code, kind, err)
+	}
+	if n := len(api.Requests()); n != 1 {
+		t.Errorf("made %d requests, want 1 with [defaults] retries = 0", n)
+	}
+
+	out, err := runFTI(t, "config", "get", "timeout", "--show-origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out); got != "defaults\t10ms" {
+		t.Errorf("config get timeout --show-origin = %q, want the [defaults] value", got)
+	}
+
+	if _, err := runFTI(t, "tokens", "list", "--no-cache", "--timeout", "5s"); err != nil {
+		t.Errorf("--timeout did not override [defaults]: %v", err)
+	}
+}
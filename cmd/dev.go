@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+	"github.com/spf13/cobra"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Developer tools",
+}
+
+// ── dev mock-server ──────────────────────────────────────────────────────────
+
+var (
+	mockAddr       string
+	mockFixtures   string
+	mockLatency    time.Duration
+	mockJitter     time.Duration
+	mockErrorRate  float64
+	mockErrorCodes string
+	mockRateLimit  int
+	mockQuiet      bool
+
+	mockStreamInterval  time.Duration
+	mockStreamDropAfter int
+	mockNoStream        bool
+)
+
+var devMockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Run a local stand-in for the Fan Token Intel API",
+	Long: `Serve realistic fixture data for every endpoint fti uses, so the CLI can
+be developed and tested without the real backend:
+
+  fti dev mock-server --addr 127.0.0.1:8787 &
+  FTI_API_URL=http://127.0.0.1:8787 fti tokens list
+
+Signals and auth/me accept any non-empty API key. Whale trades and signal
+changes are also pushed as Server-Sent Events on /api/v1/stream/whales and
+/api/v1/stream/signals, which "whales --watch" and "signals active --watch" use.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var codes []int
+		for _, s := range strings.Split(mockErrorCodes, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || code < 400 || code > 599 {
+				return &internal.UsageError{Err: fmt.Errorf("invalid --error-codes entry %q", s)}
+			}
+			codes = append(codes, code)
+		}
+		if mockErrorRate < 0 || mockErrorRate > 1 {
+			return &internal.UsageError{Err: fmt.Errorf("--error-rate must be between 0 and 1")}
+		}
+		if mockFixtures != "" {
+			if _, err := os.Stat(mockFixtures); err != nil {
+				return &internal.UsageError{Err: fmt.Errorf("--fixtures: %w", err)}
+			}
+		}
+
+		srv := &mockapi.Server{
+			FixtureDir: mockFixtures,
+			Latency:    mockLatency,
+			Jitter:     mockJitter,
+			ErrorRate:  mockErrorRate,
+			ErrorCodes: codes,
+			RateLimit:  mockRateLimit,
+
+			StreamInterval:  mockStreamInterval,
+			StreamDropAfter: mockStreamDropAfter,
+			NoStream:        mockNoStream,
+		}
+		if !mockQuiet {
+			srv.Log = os.Stderr
+		}
+
+		ln, err := net.Listen("tcp", mockAddr)
+		if err != nil {
+			return err
+		}
+		httpSrv := &http.Server{Handler: srv.Handler()}
+
+		url := "http://" + ln.Addr().String()
+		internal.Bold.Printf("Mock API listening on %s\n", url)
+		internal.Dim.Printf("  export FTI_API_URL=%s\n\n", url)
+
+		ctx := cmd.Context()
+		go func() {
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			httpSrv.Shutdown(shutdown) //nolint:errcheck
+		}()
+
+		if err := httpSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	devMockServerCmd.Flags().StringVar(&mockAddr, "addr", "127.0.0.1:8787", "Listen address")
+	devMockServerCmd.Flags().StringVar(&mockFixtures, "fixtures", "", "Directory of JSON fixtures overriding the built-in data")
+	devMockServerCmd.Flags().DurationVar(&mockLatency, "latency", 0, "Delay added to every response")
+	devMockServerCmd.Flags().DurationVar(&mockJitter, "jitter", 0, "Random extra delay up to this duration")
+	devMockServerCmd.Flags().Float64Var(&mockErrorRate, "error-rate", 0, "Fraction of requests (0-1) answered with an injected error")
+	devMockServerCmd.Flags().StringVar(&mockErrorCodes, "error-codes", "500", "Comma-separated statuses to inject (e.g. 401,404,429,500)")
+	devMockServerCmd.Flags().IntVar(&mockRateLimit, "rate-limit", 0, "Requests per minute before answering 429 (0 = unlimited)")
+	devMockServerCmd.Flags().DurationVar(&mockStreamInterval, "stream-interval", 2*time.Second, "Time between events on the whale and signal streams")
+	devMockServerCmd.Flags().IntVar(&mockStreamDropAfter, "stream-drop-after", 0, "Close each stream after this many events to test reconnects (0 = never)")
+	devMockServerCmd.Flags().BoolVar(&mockNoStream, "no-stream", false, "Answer the stream endpoints with 404 to test the polling fallback")
+	devMockServerCmd.Flags().BoolVar(&mockQuiet, "quiet", false, "Don't log requests")
+
+	devCmd.AddCommand(devMockServerCmd)
+	rootCmd.AddCommand(devCmd)
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
+	"github.com/spf13/cobra"
+)
+
+type doctorCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration, proxy, TLS and API connectivity",
+	Long: `Show the effective network settings and where each came from (flag,
+env, project, profile, file or default), then check that the CA bundle and
+client certificate load, that the API is reachable through the configured
+proxy, and that the API key is accepted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		cfgPath, _ := internal.ConfigPath()
+		cfg, cfgErr := internal.LoadConfig()
+
+		settings := map[string]setting{}
+		var checks []doctorCheck
+		check := func(name string, err error, detail string) {
+			if err != nil {
+				checks = append(checks, doctorCheck{name, false, err.Error()})
+				return
+			}
+			checks = append(checks, doctorCheck{name, true, detail})
+		}
+
+		if cfgErr != nil {
+			check("config", cfgErr, "")
+		} else if _, err := os.Stat(cfgPath); err == nil {
+			check("config", nil, "loaded "+cfgPath)
+		} else {
+			check("config", nil, "no config file, using defaults")
+		}
+		if cfg.ProjectFile != "" {
+			check("project config", nil, "loaded "+cfg.ProjectFile)
+		}
+
+		settings["profile"] = setting{"", "default"}
+		if name, origin := internal.ActiveProfile(cfg); name != "" {
+			settings["profile"] = setting{name, origin}
+		}
+		settings["api_url"] = apiURLSetting(cfg)
+		key := resolveSetting(cfg, "api_key")
+		if key.Origin != "flag" && key.Origin != "env" && (cfg.APIKeyCmd != "" || cfg.APIKeyStore != "") {
+			v, source, err := internal.ConfigAPIKey(cfg)
+			check("api_key", err, "read from "+source)
+			origin := source
+			if profileSetsKey(cfg) {
+				origin = "profile " + cfg.Profile + ", " + source
+			}
+			key = setting{v, origin}
+		}
+		settings["api_key"] = setting{internal.MaskSecret(key.Value), key.Origin}
+		if key.Value == "" {
+			settings["api_key"] = key
+		}
+
+		topts, ts := transportSettings(cfg)
+		for k, v := range ts {
+			settings[k] = v
+		}
+		if topts.Proxy == "" {
+			first, _, _ := strings.Cut(settings["api_url"].Value, ",")
+			req, _ := http.NewRequest("GET", first, nil)
+			if u, _ := http.ProxyFromEnvironment(req); u != nil {
+				settings["proxy"] = setting{u.String(), "env"}
+			}
+		}
+
+		if topts.Proxy != "" {
+			_, err := internal.ParseProxy(topts.Proxy)
+			check("proxy", err, topts.Proxy)
+		}
+		if topts.CABundle != "" {
+			_, n, err := internal.LoadCABundle(topts.CABundle)
+			check("ca_bundle", err, fmt.Sprintf("%d certificate(s) added to the system roots", n))
+		}
+		if topts.ClientCert != "" || topts.ClientKey != "" {
+			cert, err := internal.LoadClientCert(topts.ClientCert, topts.ClientKey)
+			detail := ""
+			if err == nil && cert.Leaf != nil {
+				detail = fmt.Sprintf("%s, expires %s", cert.Leaf.Subject, cert.Leaf.NotAfter.Format("2006-01-02"))
+				if time.Now().After(cert.Leaf.NotAfter) {
+					err = fmt.Errorf("%s expired on %s", cert.Leaf.Subject, cert.Leaf.NotAfter.Format("2006-01-02"))
+				}
+			}
+			check("client_cert", err, detail)
+		}
+		if topts.InsecureSkipVerify {
+			checks = append(checks, doctorCheck{"insecure_skip_verify", true, "certificate verification disabled"})
+		}
+
+		if cfgErr == nil {
+			var detail string
+			reachable := false
+			c, err := newClient(key.Value)
+			var t *internal.Client
+			if err == nil {
+				t = c.core
+				t.Retries = 0
+			}
+			switch {
+			case err != nil:
+				check("connect", err, "")
+			case t.Endpoints != nil:
+				// Check every mirror; one being down is worth knowing
+				// even though requests would fail over.
+				down := t.Endpoints.Status()
+				for i, u := range t.Endpoints.URLs {
+					detail, err = probeHealth(ctx, t, u)
+					if until, ok := down[u]; ok && err == nil && time.Now().Before(until) {
+						detail += fmt.Sprintf(", cooling down until %s", until.Format("15:04:05"))
+					}
+					check(fmt.Sprintf("connect[%d] %s", i, u), err, detail)
+					reachable = reachable || err == nil
+				}
+			default:
+				detail, err = probeHealth(ctx, t, t.BaseURL)
+				check("connect", err, detail)
+				reachable = err == nil
+			}
+			if reachable && key.Value != "" {
+				me, _, err := c.Me(ctx)
+				if err == nil {
+					detail = fmt.Sprintf("%s (%s tier, %d req/min)", me.Name, me.Tier, me.RateLimitPerMin)
+				}
+				check("auth", err, detail)
+			}
+		}
+
+		failed := 0
+		for _, ch := range checks {
+			if !ch.OK {
+				failed++
+			}
+		}
+
+		if jsonOut {
+			raw, err := json.Marshal(map[string]interface{}{
+				"settings": settings,
+				"checks":   checks,
+			})
+			if err != nil {
+				return err
+			}
+			internal.PrintJSON(raw)
+		} else {
+			printDoctor(settings, checks)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+// apiURLSetting mirrors internal.ResolveBaseURLs, recording the origin.
+// Several URLs are shown comma-separated.
+func apiURLSetting(cfg internal.Config) setting {
+	if v := os.Getenv("FTI_API_URL"); v != "" {
+		return setting{strings.Join(internal.NormalizeBaseURLs(strings.Split(v, ",")), ","), "env"}
+	}
+	p := cfg.Profiles[cfg.Profile]
+	origin := configOrigin(cfg, "api_url", p.APIURL != "" || len(p.APIURLs) > 0)
+	if urls := internal.NormalizeBaseURLs(cfg.APIURLs); len(urls) > 0 {
+		return setting{strings.Join(urls, ","), origin}
+	}
+	if cfg.APIURL != "" {
+		return setting{cfg.APIURL, origin}
+	}
+	return setting{fti.DefaultBaseURL, "default"}
+}
+
+// profileSetsKey reports whether the active profile supplies the API key.
+func profileSetsKey(cfg internal.Config) bool {
+	p := cfg.Profiles[cfg.Profile]
+	return p.APIKey != "" || p.APIKeyCmd != "" || p.APIKeyStore != ""
+}
+
+// probeHealth requests base's /health through the client's transport and
+// describes the connection, including the negotiated TLS version and server
+// issuer.
+func probeHealth(ctx context.Context, c *internal.Client, base string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", base+"/health", nil)
+	if err != nil {
+		return "", err
+	}
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	detail := fmt.Sprintf("GET /health %d in %s", resp.StatusCode, time.Since(start).Round(time.Millisecond))
+	if resp.TLS != nil {
+		detail += ", " + tls.VersionName(resp.TLS.Version)
+		if certs := resp.TLS.PeerCertificates; len(certs) > 0 {
+			detail += ", issuer " + certs[0].Issuer.String()
+		}
+	}
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("%s", detail)
+	}
+	return detail, nil
+}
+
+func printDoctor(settings map[string]setting, checks []doctorCheck) {
+	names := make([]string, 0, len(settings))
+	for k := range settings {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	internal.Bold.Println("\nSettings")
+	for _, k := range names {
+		s := settings[k]
+		v := s.Value
+		if v == "" {
+			v = internal.Dim.Sprint("—")
+		}
+		fmt.Printf("  %-21s %s %s\n", k, v, internal.Dim.Sprintf("(%s)", s.Origin))
+	}
+
+	internal.Bold.Println("\nChecks")
+	for _, ch := range checks {
+		mark := internal.Green.Sprint("✓")
+		if !ch.OK {
+			mark = internal.Red.Sprint("✗")
+		}
+		fmt.Printf("  %s %-21s %s\n", mark, ch.Name, ch.Detail)
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -1,15 +1,282 @@
 package cmd
 
-import "net/url"
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
-// buildQuery converts a map of string key/value pairs into url.Values,
-// skipping empty values.
-func buildQuery(params map[string]string) url.Values {
-	q := url.Values{}
-	for k, v := range params {
-		if v != "" {
-			q.Set(k, v)
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
+)
+
+// printJSON pretty-prints an API response, carrying its metadata as "_meta".
+func printJSON(res *internal.Response) {
+	internal.PrintJSONMeta(res.Body, res.Meta)
+}
+
+// defaultMaxRecords caps --all-pages unless --max-records says otherwise.
+const defaultMaxRecords = 1000
+
+// printNDJSON writes the first n records of the array under key in res as
+// one compact JSON object per line, for streaming paged results.
+func printNDJSON(res *internal.Response, key string, n int) error {
+	var env map[string]json.RawMessage
+	if err := json.Unmarshal(res.Body, &env); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(env[key], &records); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	internal.PrintStaleMeta(res.Meta)
+	var buf bytes.Buffer
+	for _, r := range records[:min(n, len(records))] {
+		buf.Reset()
+		if err := json.Compact(&buf, r); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			return err
 		}
 	}
-	return q
+	return nil
+}
+
+// staleFooter prints a warning under table output when res was served from
+// stored data rather than the live API.
+func staleFooter(res *internal.Response) {
+	if notice := internal.StaleNotice(res.Meta); notice != "" {
+		fmt.Println(notice)
+	}
+}
+
+// prompt prints label and reads one trimmed line from r. It returns early
+// with ctx.Err() if the context is cancelled while waiting for input.
+func prompt(ctx context.Context, r *bufio.Reader, label string) (string, error) {
+	fmt.Print(label)
+
+	line := make(chan string, 1)
+	go func() {
+		s, _ := r.ReadString('\n')
+		line <- s
+	}()
+
+	select {
+	case <-ctx.Done():
+		fmt.Println()
+		return "", ctx.Err()
+	case s := <-line:
+		return strings.TrimSpace(s), nil
+	}
+}
+
+// setting is a resolved option and where its value came from: "flag",
+// "env", "defaults", "project", "profile <name>", "file" or "default".
+type setting struct {
+	Value  string `json:"value"`
+	Origin string `json:"origin"`
+}
+
+// stringSetting resolves a string option from its persistent flag, then
+// the config value.
+func stringSetting(cfg internal.Config, flag, flagValue, cfgValue string) setting {
+	if origin := flagOrigin(flag); origin != "" {
+		return setting{flagValue, origin}
+	}
+	switch {
+	case cfgValue != "":
+		return setting{cfgValue, configOrigin(cfg, strings.ReplaceAll(flag, "-", "_"), false)}
+	}
+	return setting{"", "default"}
+}
+
+// transportSettings resolves the proxy and TLS options from flags, then
+// ~/.fti/config.toml.
+func transportSettings(cfg internal.Config) (internal.TransportOptions, map[string]setting) {
+	s := map[string]setting{
+		"proxy":       stringSetting(cfg, "proxy", proxyURL, cfg.Proxy),
+		"ca_bundle":   stringSetting(cfg, "ca-bundle", caBundle, cfg.CABundle),
+		"client_cert": stringSetting(cfg, "client-cert", clientCert, cfg.ClientCert),
+		"client_key":  stringSetting(cfg, "client-key", clientKey, cfg.ClientKey),
+	}
+	insecure := setting{"false", "default"}
+	switch origin := flagOrigin("insecure-skip-verify"); {
+	case origin != "":
+		insecure = setting{fmt.Sprint(insecureSkipVerify), origin}
+	case cfg.InsecureSkipVerify:
+		insecure = setting{"true", configOrigin(cfg, "insecure_skip_verify", false)}
+	}
+	s["insecure_skip_verify"] = insecure
+
+	return internal.TransportOptions{
+		Proxy:              s["proxy"].Value,
+		CABundle:           s["ca_bundle"].Value,
+		ClientCert:         s["client_cert"].Value,
+		ClientKey:          s["client_key"].Value,
+		InsecureSkipVerify: insecure.Value == "true",
+	}, s
+}
+
+// warnedInsecure keeps the insecure-skip-verify warning to once per run.
+var warnedInsecure bool
+
+// warnedSchemas keeps schema drift warnings to once per endpoint per run.
+var (
+	warnedSchemaMu sync.Mutex
+	warnedSchemas  = map[string]bool{}
+)
+
+// warnSchemaDrift reports a response that no longer matches the schema
+// this build expects, unless --strict already turned it into an error.
+// The same drift is reported once a day across runs.
+func warnSchemaDrift(e *internal.SchemaError) {
+	warnedSchemaMu.Lock()
+	defer warnedSchemaMu.Unlock()
+	if warnedSchemas[e.Schema] {
+		return
+	}
+	warnedSchemas[e.Schema] = true
+	if !internal.ShouldWarnDrift(e) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: %s\n  The API may have changed; results could be incomplete. Use --strict to fail instead, or upgrade fti.\n",
+		internal.Yellow.Sprint("warning"), e)
+}
+
+// newClient creates an API client for the resolved base URLs, failing
+// over between them when several are configured. Timeout and
+// retry settings come from the global flags when set, then
+// ~/.fti/config.toml, as do the proxy and TLS settings. The client shares a rate limiter with other fti
+// processes using the same key and reads GET responses through the on-disk
+// cache, which also backs --offline. FTI_RECORD / FTI_REPLAY swap in a
+// cassette transport and bypass the cache so every request hits it.
+func newClient(key string) (*apiClient, error) {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	urls := internal.ResolveBaseURLs(fti.DefaultBaseURL)
+	c := internal.NewClient(urls[0], key)
+	c.UserAgent = internal.UserAgent(Version)
+	if len(urls) > 1 {
+		c.Endpoints = internal.NewEndpoints(urls)
+		if cfg.EndpointCooldown != "" {
+			d, err := time.ParseDuration(cfg.EndpointCooldown)
+			if err != nil {
+				return nil, fmt.Errorf("config endpoint_cooldown: %w", err)
+			}
+			c.Endpoints.Cooldown = d
+		}
+	}
+
+	switch {
+	case flagOrigin("retries") != "":
+		c.Retries = retries
+	case cfg.Retries != nil:
+		c.Retries = *cfg.Retries
+	}
+	switch {
+	case flagOrigin("retry-max-wait") != "":
+		c.RetryMaxWait = retryMaxWait
+	case cfg.RetryMaxWait != "":
+		d, err := time.ParseDuration(cfg.RetryMaxWait)
+		if err != nil {
+			return nil, fmt.Errorf("config retry_max_wait: %w", err)
+		}
+		c.RetryMaxWait = d
+	}
+	switch {
+	case flagOrigin("timeout") != "":
+		c.Timeout = timeout
+	case cfg.Timeout != "":
+		d, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("config timeout: %w", err)
+		}
+		c.Timeout = d
+	}
+	if c.Retries < 0 {
+		c.Retries = 0
+	}
+	c.Strict = cfg.Strict
+	if flagOrigin("strict") != "" {
+		c.Strict = strict
+	}
+	c.OnSchemaDrift = warnSchemaDrift
+	switch {
+	case flagOrigin("max-response-size") != "":
+		n, err := internal.ParseSize(maxRespSize)
+		if err != nil {
+			return nil, &internal.UsageError{Err: fmt.Errorf("--max-response-size: %w", err)}
+		}
+		c.MaxResponseSize = n
+	case cfg.MaxResponseSize != "":
+		n, err := internal.ParseSize(cfg.MaxResponseSize)
+		if err != nil {
+			return nil, fmt.Errorf("config max_response_size: %w", err)
+		}
+		c.MaxResponseSize = n
+	}
+
+	topts, _ := transportSettings(cfg)
+	if !topts.IsZero() {
+		t, err := internal.NewTransport(topts)
+		if err != nil {
+			return nil, err
+		}
+		c.HTTPClient.Transport = t
+		if topts.InsecureSkipVerify && !warnedInsecure {
+			warnedInsecure = true
+			fmt.Fprintln(os.Stderr, internal.Yellow.Sprint("warning: TLS certificate verification is disabled (insecure_skip_verify)"))
+		}
+	}
+
+	if l, err := internal.NewRateLimiter(key); err == nil {
+		c.Limiter = l
+	}
+	if cache, err := internal.NewCache(); err == nil {
+		c.Cache = cache
+		c.CacheTTL = cacheTTL
+		c.NoCache = noCache
+	}
+	c.Offline = offline
+
+	if tracer == nil && (verbose || traceFile != "") {
+		var log io.Writer
+		if verbose {
+			log = os.Stderr
+		}
+		tracer = internal.NewTracer(log, traceFile != "", Version)
+	}
+	c.Tracer = tracer
+
+	cassette, err := internal.CassetteFromEnv(key)
+	if err != nil {
+		return nil, err
+	}
+	if cassette != nil {
+		cassette.Next = c.HTTPClient.Transport
+		c.HTTPClient.Transport = cassette
+		c.Cache = nil
+		if cassette.Replay {
+			c.Limiter = nil
+		}
+	}
+	return &apiClient{fti.WrapInternal(c), c}, nil
+}
+
+// apiClient is the SDK client commands call, with the internal client
+// behind it for the settings the SDK does not expose.
+type apiClient struct {
+	*fti.Client
+	core *internal.Client
 }
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+)
+
+func TestTokensList(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+
+	out, err := runFTI(t, "tokens", "list", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []struct {
+		Symbol string  `json:"symbol"`
+		Price  float64 `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(out), &tokens); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	if len(tokens) == 0 || tokens[0].Symbol == "" {
+		t.Fatalf("got %+v, want tokens", tokens)
+	}
+}
+
+func TestTokensGetNotFound(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+
+	_, err := runFTI(t, "tokens", "get", "NOSUCH")
+	if err == nil {
+		t.Fatal("want an error for an unknown token")
+	}
+	if code, kind := internal.Classify(err); code != internal.ExitNotFound {
+		t.Errorf("exit code = %d (%s), want %d: %v", code, kind, internal.ExitNotFound, err)
+	}
+}
+
+func TestWhalesAllPages(t *testing.T) {
+	api := newMockAPI(t, &mockapi.Server{})
+
+	out, err := runFTI(t, "whales", "--all", "--json", "--all-pages", "--limit", "5", "--max-records", "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		var trade map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &trade); err != nil {
+			t.Fatalf("line %q is not a JSON record: %v", sc.Text(), err)
+		}
+		seen[sc.Text()] = true
+	}
+	if len(seen) <= 5 {
+		t.Fatalf("got %d records, want more than one page of 5", len(seen))
+	}
+
+	pages := 0
+	for _, r := range api.Requests() {
+		if strings.HasPrefix(r, "/api/whales/combined") {
+			pages++
+		}
+	}
+	if want := (len(seen) + 4) / 5; pages < want {
+		t.Errorf("fetched %d pages for %d records, want at least %d", pages, len(seen), want)
+	}
+}
+
+func TestSignalsActive(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+
+	out, err := runFTI(t, "signals", "active", "--json", "--min-confidence", "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Signals []struct {
+			Token      string  `json:"token"`
+			Confidence float64 `json:"confidence"`
+		} `json:"signals"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	if len(resp.Signals) == 0 {
+		t.Fatalf("no signals in %s", out)
+	}
+
+	t.Setenv("FTI_API_KEY", "")
+	if _, err := runFTI(t, "signals", "active", "--no-cache"); err == nil {
+		t.Error("want an error without an API key")
+	} else if code, _ := internal.Classify(err); code != internal.ExitAuth {
+		t.Errorf("exit code = %d without a key, want %d: %v", code, internal.ExitAuth, err)
+	}
+}
+
+// rateLimitedAPI answers the first limited requests with 429 and
+// Retry-After: 1, then serves the mock API.
+func rateLimitedAPI(t *testing.T, limited int32) *mockAPI {
+	var n atomic.Int32
+	return newMockAPI(t, &mockapi.Server{}, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if n.Add(1) <= limited {
+				w.Header().Set("Retry-After", "1")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"detail":"Rate limit exceeded"}`)) //nolint:errcheck
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	rateLimitedAPI(t, 1)
+
+	start := time.Now()
+	if _, err := runFTI(t, "tokens", "list", "--json"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want Retry-After's 1s", elapsed)
+	}
+}
+
+func TestRateLimitedExitCode(t *testing.T) {
+	api := rateLimitedAPI(t, 100)
+
+	_, err := runFTI(t, "tokens", "list", "--retries", "1")
+	if err == nil {
+		t.Fatal("want a rate limit error")
+	}
+	if code, kind := internal.Classify(err); code != internal.ExitRateLimited {
+		t.Errorf("exit code = %d (%s), want %d: %v", code, kind, internal.ExitRateLimited, err)
+	}
+	if n := len(api.Requests()); n != 2 {
+		t.Errorf("made %d requests, want 2 with --retries 1", n)
+	}
+}
+
+func TestWatchReportsFirstFetchError(t *testing.T) {
+	// The stream stays quiet, so only the failed fetch of the current
+	// trades can end the command.
+	api := newMockAPI(t, &mockapi.Server{StreamInterval: time.Hour}, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/api/whales/") {
+				http.Error(w, `{"detail":"boom"}`, http.StatusInternalServerError)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := runFTI(t, "whales", "--all", "--watch", "--retries", "0")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if code, kind := internal.Classify(err); code != internal.ExitServer {
+			t.Errorf("exit code = %d (%s), want %d: %v", code, kind, internal.ExitServer, err)
+		}
+	case <-time.After(5 * time.Second):
+		api.CloseClientConnections()
+		t.Fatal("whales --watch is still waiting for a stream event")
+	}
+}
+
+func TestAllPagesTableFooter(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+	args := []string{"whales", "--all", "--all-pages", "--limit", "5", "--max-records", "12"}
+	if _, err := runFTI(t, args...); err != nil {
+		t.Fatal(err)
+	}
+
+	// Offline, every page is stale; the notice comes once, at the end.
+	out, err := runFTI(t, append(args, "--offline")...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out, "TIME"); n != 1 {
+		t.Errorf("table header printed %d times:\n%s", n, out)
+	}
+	if n := strings.Count(out, "stale data"); n != 1 {
+		t.Errorf("stale notice printed %d times:\n%s", n, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if last := lines[len(lines)-1]; !strings.Contains(last, "stale data") {
+		t.Errorf("output ends with %q, want the stale notice", last)
+	}
+}
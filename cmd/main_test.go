@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// mockAPI starts the mock API for a test and records the request URIs it
+// receives. The fti environment points at it, with state in a temp dir.
+type mockAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []string
+}
+
+// newMockAPI serves srv, wrapped in wrap if given, e.g. to inject errors.
+func newMockAPI(t *testing.T, srv *mockapi.Server, wrap ...func(http.Handler) http.Handler) *mockAPI {
+	t.Helper()
+	m := &mockAPI{}
+	h := srv.Handler()
+	for _, w := range wrap {
+		h = w(h)
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.requests = append(m.requests, r.URL.RequestURI())
+		m.mu.Unlock()
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(m.Close)
+
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_API_URL", m.URL)
+	t.Setenv("FTI_API_KEY", "ti_test_key")
+	t.Setenv("FTI_PROFILE", "")
+	return m
+}
+
+// Requests returns the request URIs received so far.
+func (m *mockAPI) Requests() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.requests...)
+}
+
+type flagState struct{ def, usage string }
+
+var (
+	builtinFlagsOnce sync.Once
+	builtinFlags     = map[*pflag.Flag]flagState{}
+)
+
+// runFTI runs fti with args in-process, as Execute does, and returns what
+// it printed on stdout. Flags are reset to their built-in defaults first,
+// since cobra keeps their values between runs.
+func runFTI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	stdout, _, err := runFTIStderr(t, args...)
+	return stdout, err
+}
+
+// runFTIStderr is runFTI, also returning what fti printed on stderr.
+func runFTIStderr(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	resetFlags()
+	loadConfigDefaults()
+	commandStarted = false
+	jsonOut = false
+
+	dir := t.TempDir()
+	out, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errOut, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedOut, savedErr, colorOut := os.Stdout, os.Stderr, color.Output
+	os.Stdout, os.Stderr, color.Output = out, errOut, out
+	rootCmd.SetArgs(args)
+	runErr := rootCmd.ExecuteContext(context.Background())
+	os.Stdout, os.Stderr, color.Output = savedOut, savedErr, colorOut
+	out.Close()
+	errOut.Close()
+
+	return readFile(t, out.Name()), readFile(t, errOut.Name()), runErr
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// resetFlags puts every flag back to its built-in default and unset state.
+func resetFlags() {
+	builtinFlagsOnce.Do(func() {
+		walkFlags(rootCmd, func(f *pflag.Flag) { builtinFlags[f] = flagState{f.DefValue, f.Usage} })
+	})
+	configDefaults = map[*cobra.Command]map[*pflag.Flag]string{}
+	walkFlags(rootCmd, func(f *pflag.Flag) {
+		s := builtinFlags[f]
+		f.DefValue, f.Usage = s.def, s.usage
+		f.Value.Set(s.def) //nolint:errcheck
+		f.Changed = false
+	})
+}
+
+func walkFlags(c *cobra.Command, fn func(*pflag.Flag)) {
+	c.LocalFlags().VisitAll(fn)
+	for _, sub := range c.Commands() {
+		walkFlags(sub, fn)
+	}
+}
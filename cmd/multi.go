@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
+)
+
+// defaultConcurrency is how many symbols are fetched at once.
+const defaultConcurrency = 4
+
+// readSymbols upper-cases and de-duplicates symbol arguments, replacing "-"
+// with symbols read from stdin (separated by whitespace or commas).
+func readSymbols(args []string, stdin io.Reader) ([]string, error) {
+	var raw []string
+	for _, a := range args {
+		if a != "-" {
+			raw = append(raw, a)
+			continue
+		}
+		sc := bufio.NewScanner(stdin)
+		for sc.Scan() {
+			raw = append(raw, strings.FieldsFunc(sc.Text(), func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})...)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("reading symbols from stdin: %w", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	var symbols []string
+	for _, s := range raw {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		symbols = append(symbols, s)
+	}
+	if len(symbols) == 0 {
+		return nil, &internal.UsageError{Err: errors.New("no symbols given")}
+	}
+	return symbols, nil
+}
+
+// symbolResult is the outcome of fetching one symbol.
+type symbolResult[T any] struct {
+	Symbol string
+	Value  T
+	Res    *fti.Response
+	Err    error
+}
+
+// fetchAll calls fetch for every symbol using at most concurrency workers
+// and returns the results in input order.
+func fetchAll[T any](ctx context.Context, symbols []string, concurrency int,
+	fetch func(context.Context, string) (T, *fti.Response, error)) []symbolResult[T] {
+
+	results := make([]symbolResult[T], len(symbols))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(symbols)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := &results[i]
+				r.Symbol = symbols[i]
+				r.Value, r.Res, r.Err = fetch(ctx, symbols[i])
+			}
+		}()
+	}
+	for i := range symbols {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// runSymbols fetches every symbol and prints the results in input order,
+// with render for table output. A single symbol behaves exactly like the
+// original one-symbol commands. With several, failures are reported per
+// symbol — inline in tables, as {"symbol", "error"} items with --json —
+// and the command fails only after everything has been printed.
+func runSymbols[T any](ctx context.Context, symbols []string, concurrency int,
+	fetch func(context.Context, string) (T, *fti.Response, error),
+	render func(T, *fti.Response)) error {
+
+	if concurrency < 1 {
+		return &internal.UsageError{Err: fmt.Errorf("--concurrency must be at least 1, got %d", concurrency)}
+	}
+	results := fetchAll(ctx, symbols, concurrency, fetch)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(results) == 1 {
+		r := results[0]
+		if r.Err != nil {
+			return r.Err
+		}
+		if jsonOut {
+			printJSON(r.Res)
+		} else {
+			render(r.Value, r.Res)
+		}
+		return nil
+	}
+
+	var failed []error
+	if jsonOut {
+		items := make([]interface{}, len(results))
+		for i, r := range results {
+			if r.Err != nil {
+				failed = append(failed, r.Err)
+				obj, _ := errorObject(r.Err)
+				items[i] = map[string]interface{}{"symbol": r.Symbol, "error": obj}
+				continue
+			}
+			item := map[string]interface{}{"symbol": r.Symbol, "data": json.RawMessage(r.Res.Body)}
+			if !r.Res.Meta.IsZero() {
+				item["_meta"] = r.Res.Meta
+			}
+			items[i] = item
+		}
+		raw, err := json.Marshal(items)
+		if err != nil {
+			return err
+		}
+		internal.PrintJSON(raw)
+	} else {
+		for _, r := range results {
+			if r.Err != nil {
+				failed = append(failed, r.Err)
+				fmt.Fprintf(os.Stderr, "\n%s %s: %s\n", internal.Red.Sprint("error"), r.Symbol, r.Err)
+				continue
+			}
+			render(r.Value, r.Res)
+		}
+	}
+
+	switch {
+	case len(failed) == 0:
+		return nil
+	case len(failed) == len(results):
+		// Nothing succeeded: exit with the first failure's own code.
+		return fmt.Errorf("all %d symbols failed: %w", len(results), failed[0])
+	}
+	return fmt.Errorf("%d of %d symbols failed", len(failed), len(results))
+}
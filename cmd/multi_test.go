@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+)
+
+type symbolItem struct {
+	Symbol string          `json:"symbol"`
+	Data   json.RawMessage `json:"data"`
+	Error  *struct {
+		Kind     string `json:"kind"`
+		ExitCode int    `json:"exit_code"`
+	} `json:"error"`
+}
+
+func decodeSymbolItems(t *testing.T, out string) []symbolItem {
+	t.Helper()
+	var items []symbolItem
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	return items
+}
+
+func TestReadSymbols(t *testing.T) {
+	got, err := readSymbols([]string{"psg", "-", "bar"}, strings.NewReader("juv, city\nPSG\tacm\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"PSG", "JUV", "CITY", "ACM", "BAR"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readSymbols = %q, want %q", got, want)
+	}
+
+	_, err = readSymbols([]string{"-"}, strings.NewReader("\n"))
+	var usage *internal.UsageError
+	if !errors.As(err, &usage) {
+		t.Errorf("readSymbols of empty stdin = %v, want a usage error", err)
+	}
+}
+
+func TestPricesInputOrder(t *testing.T) {
+	// PSG is answered last, so only ordering by input puts it first.
+	newMockAPI(t, &mockapi.Server{}, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/tokens/PSG" {
+				time.Sleep(100 * time.Millisecond)
+			}
+			h.ServeHTTP(w, r)
+		})
+	})
+
+	out, err := runFTI(t, "prices", "PSG", "BAR", "JUV", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var symbols []string
+	for _, it := range decodeSymbolItems(t, out) {
+		symbols = append(symbols, it.Symbol)
+	}
+	if want := []string{"PSG", "BAR", "JUV"}; !reflect.DeepEqual(symbols, want) {
+		t.Errorf("--json order = %q, want %q", symbols, want)
+	}
+
+	out, err = runFTI(t, "prices", "PSG", "BAR", "JUV", "--no-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	psg, bar, juv := strings.Index(out, "PSG"), strings.Index(out, "BAR"), strings.Index(out, "JUV")
+	if psg < 0 || !(psg < bar && bar < juv) {
+		t.Errorf("table order wrong:\n%s", out)
+	}
+}
+
+func TestPricesPartialFailure(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+
+	out, err := runFTI(t, "prices", "PSG", "NOSUCH", "BAR", "--json")
+	if code, kind := internal.Classify(err); code != internal.ExitError {
+		t.Errorf("exit code = %d (%s) for 1 of 3 failing, want %d: %v", code, kind, internal.ExitError, err)
+	}
+	items := decodeSymbolItems(t, out)
+	if len(items) != 3 {
+		t.Fatalf("%d items, want 3: %s", len(items), out)
+	}
+	if items[0].Data == nil || items[2].Data == nil {
+		t.Errorf("PSG and BAR lack data: %s", out)
+	}
+	if e := items[1].Error; items[1].Symbol != "NOSUCH" || e == nil || e.ExitCode != internal.ExitNotFound {
+		t.Errorf("item for NOSUCH = %+v, want a not-found error", items[1])
+	}
+
+	out, stderr, err := runFTIStderr(t, "prices", "PSG", "NOSUCH", "BAR")
+	if err == nil {
+		t.Error("want an error for 1 of 3 failing")
+	}
+	if !strings.Contains(out, "PSG") || !strings.Contains(out, "BAR") {
+		t.Errorf("table lacks the symbols that succeeded:\n%s", out)
+	}
+	if !strings.Contains(stderr, "error NOSUCH:") {
+		t.Errorf("stderr lacks the NOSUCH error:\n%s", stderr)
+	}
+}
+
+func TestPricesAllFailed(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+
+	_, err := runFTI(t, "prices", "NOSUCH", "NOPE")
+	if code, kind := internal.Classify(err); code != internal.ExitNotFound {
+		t.Errorf("exit code = %d (%s) for all failing, want %d: %v", code, kind, internal.ExitNotFound, err)
+	}
+}
+
+func TestTokensGetFromStdin(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+	rootCmd.SetIn(strings.NewReader("bar\npsg, bar\n"))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	out, err := runFTI(t, "tokens", "get", "-", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var symbols []string
+	for _, it := range decodeSymbolItems(t, out) {
+		symbols = append(symbols, it.Symbol)
+	}
+	if want := []string{"BAR", "PSG"}; !reflect.DeepEqual(symbols, want) {
+		t.Errorf("symbols from stdin = %q, want %q", symbols, want)
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+)
+
+// staleMeta returns the "_meta" object fti wrote on stderr, if any.
+func staleMeta(t *testing.T, stderr string) internal.Meta {
+	t.Helper()
+	for _, line := range strings.Split(stderr, "\n") {
+		var v struct {
+			Meta *internal.Meta `json:"_meta"`
+		}
+		if json.Unmarshal([]byte(line), &v) == nil && v.Meta != nil {
+			return *v.Meta
+		}
+	}
+	return internal.Meta{}
+}
+
+func TestOfflineJSON(t *testing.T) {
+	newMockAPI(t, &mockapi.Server{})
+	for _, args := range [][]string{{"tokens", "list", "--json"}, {"tokens", "get", "PSG", "--json"}} {
+		if _, err := runFTI(t, args...); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+
+	// An array has no room for _meta; it goes to stderr as JSON.
+	out, stderr, err := runFTIStderr(t, "tokens", "list", "--json", "--offline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &tokens); err != nil || len(tokens) == 0 {
+		t.Fatalf("stdout is not the stored token array: %v\n%s", err, out)
+	}
+	if meta := staleMeta(t, stderr); meta.StaleSince == nil || meta.StaleReason != "offline" {
+		t.Errorf("stderr has no stale _meta: %q", stderr)
+	}
+
+	out, err = runFTI(t, "tokens", "get", "PSG", "--json", "--offline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var token struct {
+		Meta internal.Meta `json:"_meta"`
+	}
+	if err := json.Unmarshal([]byte(out), &token); err != nil {
+		t.Fatal(err)
+	}
+	if token.Meta.StaleSince == nil || token.Meta.StaleReason != "offline" {
+		t.Errorf("_meta = %+v, want stale offline data", token.Meta)
+	}
+
+	_, err = runFTI(t, "tokens", "get", "CHZ", "--offline")
+	if code, _ := internal.Classify(err); code != internal.ExitNetwork {
+		t.Errorf("exit code = %d for nothing stored, want %d: %v", code, internal.ExitNetwork, err)
+	}
+}
+
+func TestNetworkFallback(t *testing.T) {
+	api := newMockAPI(t, &mockapi.Server{})
+	if _, err := runFTI(t, "tokens", "list", "--json"); err != nil {
+		t.Fatal(err)
+	}
+	api.Close()
+
+	out, stderr, err := runFTIStderr(t, "tokens", "list", "--json", "--no-cache", "--retries", "0")
+	if err != nil {
+		t.Fatalf("no fallback to the stored response: %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(out), "[") {
+		t.Errorf("stdout = %q, want the stored array", out)
+	}
+	if meta := staleMeta(t, stderr); meta.StaleSince == nil || meta.StaleReason != "api unreachable" {
+		t.Errorf("stderr has no stale _meta: %q", stderr)
+	}
+
+	out, err = runFTI(t, "tokens", "list", "--no-cache", "--retries", "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "stale data") {
+		t.Errorf("table has no stale footer:\n%s", out)
+	}
+}
@@ -1,11 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
 	"github.com/spf13/cobra"
 )
 
@@ -16,87 +16,57 @@ var (
 	pricesLimit    int
 )
 
+var pricesConcurrency int
+
 var pricesCmd = &cobra.Command{
-	Use:   "prices <SYMBOL>",
+	Use:   "prices <SYMBOL>... | -",
 	Short: "Current price or historical price data",
-	Args:  cobra.ExactArgs(1),
+	Long: `Current price or historical price data for one or more tokens. Pass "-"
+to read symbols from stdin. Symbols are fetched in parallel and printed in
+the order given; a failure for one symbol is reported without stopping
+the others.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		symbol := strings.ToUpper(args[0])
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, "")
+		symbols, err := readSymbols(args, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		c, err := newClient("")
+		if err != nil {
+			return err
+		}
 
+		ctx := cmd.Context()
 		if !pricesHistory {
-			return currentPrice(c, symbol)
+			return runSymbols(ctx, symbols, pricesConcurrency, c.GetToken, renderCurrentPrice)
 		}
-		return priceHistory(c, symbol)
+		history := func(ctx context.Context, symbol string) (*fti.PriceSeries, *fti.Response, error) {
+			s, res, err := c.PriceHistory(ctx, symbol, &fti.PriceHistoryOptions{
+				Interval: pricesInterval,
+				Days:     pricesDays,
+			})
+			if s != nil && s.Symbol == "" {
+				s.Symbol = symbol
+			}
+			return s, res, err
+		}
+		return runSymbols(ctx, symbols, pricesConcurrency, history, renderPriceHistory)
 	},
 }
 
-func currentPrice(c *internal.Client, symbol string) error {
-	var resp struct {
-		Token struct {
-			Symbol string `json:"symbol"`
-			Name   string `json:"name"`
-		} `json:"token"`
-		Metrics struct {
-			Price          float64 `json:"price"`
-			PriceChange1h  float64 `json:"price_change_1h"`
-			PriceChange24h float64 `json:"price_change_24h"`
-			PriceChange7d  float64 `json:"price_change_7d"`
-			Volume24h      float64 `json:"volume_24h"`
-		} `json:"metrics"`
-	}
-
-	raw, err := c.Get("/api/tokens/"+symbol, nil, &resp)
-	if err != nil {
-		return err
-	}
-
-	if jsonOut {
-		internal.PrintJSON(raw)
-		return nil
-	}
-
+func renderCurrentPrice(resp *fti.TokenDetail, res *fti.Response) {
 	internal.Bold.Printf("\n%s  %s\n\n", resp.Token.Symbol, resp.Token.Name)
 	fmt.Printf("  Price:   %s\n", internal.FormatPrice(resp.Metrics.Price))
 	fmt.Printf("  1h:      %s\n", internal.FormatChange(resp.Metrics.PriceChange1h))
 	fmt.Printf("  24h:     %s\n", internal.FormatChange(resp.Metrics.PriceChange24h))
 	fmt.Printf("  7d:      %s\n", internal.FormatChange(resp.Metrics.PriceChange7d))
 	fmt.Printf("  Vol 24h: %s\n", internal.FormatVolume(resp.Metrics.Volume24h))
+	staleFooter(res)
 	fmt.Println()
-	return nil
 }
 
-func priceHistory(c *internal.Client, symbol string) error {
-	q := buildQuery(map[string]string{
-		"interval": pricesInterval,
-		"days":     strconv.Itoa(pricesDays),
-	})
-
-	var resp struct {
-		Symbol      string `json:"symbol"`
-		PeriodHours int    `json:"period_hours"`
-		DataPoints  int    `json:"data_points"`
-		Prices      []struct {
-			Time      string  `json:"time"`
-			Price     float64 `json:"price"`
-			Volume    float64 `json:"volume"`
-			Spread    float64 `json:"spread"`
-			Liquidity float64 `json:"liquidity"`
-		} `json:"prices"`
-	}
-
-	raw, err := c.Get("/api/history/price/"+symbol, q, &resp)
-	if err != nil {
-		return err
-	}
-
-	if jsonOut {
-		internal.PrintJSON(raw)
-		return nil
-	}
-
-	internal.Bold.Printf("\n%s price history — last %d days (%s interval)\n\n", symbol, pricesDays, pricesInterval)
+func renderPriceHistory(resp *fti.PriceSeries, res *fti.Response) {
+	internal.Bold.Printf("\n%s price history — last %d days (%s interval)\n\n", resp.Symbol, pricesDays, pricesInterval)
 
 	t := internal.NewTable("TIME", "PRICE", "VOLUME", "SPREAD")
 	t.Header()
@@ -114,7 +84,7 @@ func priceHistory(c *internal.Client, symbol string) error {
 	}
 	t.Flush()
 	fmt.Printf("\n%d data points\n", resp.DataPoints)
-	return nil
+	staleFooter(res)
 }
 
 // shortTime trims the seconds from an ISO timestamp.
@@ -130,6 +100,7 @@ func init() {
 	pricesCmd.Flags().IntVar(&pricesDays, "days", 7, "Number of days of history")
 	pricesCmd.Flags().StringVar(&pricesInterval, "interval", "1h", "Candle interval (1h, 4h, 1d)")
 	pricesCmd.Flags().IntVar(&pricesLimit, "limit", 0, "Max rows to display (0 = all)")
+	pricesCmd.Flags().IntVar(&pricesConcurrency, "concurrency", defaultConcurrency, "Symbols fetched in parallel")
 
 	rootCmd.AddCommand(pricesCmd)
 }
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
 	"github.com/spf13/cobra"
 )
 
@@ -11,11 +18,29 @@ import (
 var Version = "dev"
 
 var (
-	apiKey  string
-	jsonOut bool
+	apiKey       string
+	jsonOut      bool
+	timeout      time.Duration
+	retries      int
+	retryMaxWait time.Duration
+	cacheTTL     time.Duration
+	noCache      bool
+	offline      bool
+	verbose      bool
+	traceFile    string
+	maxRespSize  string
+	strict       bool
+
+	proxyURL           string
+	caBundle           string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
 )
 
-const defaultBaseURL = "https://web-production-ad7c4.up.railway.app"
+// tracer is shared by every client in the process so --trace-file captures
+// all requests of a command.
+var tracer *internal.Tracer
 
 var rootCmd = &cobra.Command{
 	Use:     "fti",
@@ -32,19 +57,113 @@ Get an API key:
 Quick start:
   fti tokens list
   fti signals active --token PSG
-  fti whales --all`,
-	SilenceUsage: true,
+  fti whales --all
+
+Exit codes:
+  0    success
+  1    unclassified error
+  2    invalid flags, arguments or request (API 400/422)
+  3    missing or rejected API key (401/403)
+  4    not found (404)
+  5    rate limited (429)
+  6    network failure or timeout
+  7    server error (5xx)
+  130  interrupted`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	// Anything failing before this hook (unknown commands, bad flags or
+	// arguments) is reported as a usage error. Flags left unset take their
+	// defaults from the config here.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		commandStarted = true
+		return applyConfigDefaults(cmd)
+	},
 }
 
-// Execute runs the root command.
+// commandStarted is set once cobra has validated flags and arguments.
+var commandStarted bool
+
+// Execute loads the config's flag defaults, then runs the root command
+// under a context that is cancelled on SIGINT/SIGTERM, so in-flight
+// requests and waits return immediately.
+// Failures exit with one of the documented internal.Exit* codes.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	loadConfigDefaults()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	if tracer != nil && traceFile != "" {
+		if werr := tracer.WriteHAR(traceFile); werr != nil && err == nil {
+			err = werr
+		}
 	}
+
+	if err == nil {
+		return
+	}
+	if !commandStarted {
+		err = &internal.UsageError{Err: err}
+	}
+	os.Exit(printError(err))
+}
+
+// printError reports err on stderr — as a JSON object with --json — and
+// returns the exit code for it.
+func printError(err error) int {
+	code, _ := internal.Classify(err)
+
+	if !jsonOut {
+		if code == internal.ExitInterrupted {
+			fmt.Fprintln(os.Stderr, "interrupted")
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", internal.Red.Sprint("error"), err)
+		}
+		return code
+	}
+
+	out, code := errorObject(err)
+	enc := json.NewEncoder(os.Stderr)
+	enc.Encode(map[string]interface{}{"error": out}) //nolint:errcheck
+	return code
+}
+
+// errorObject describes err for JSON output, with API error details when
+// available, and returns its exit code.
+func errorObject(err error) (interface{}, int) {
+	code, kind := internal.Classify(err)
+	out := struct {
+		Kind      string `json:"kind"`
+		Message   string `json:"message"`
+		ExitCode  int    `json:"exit_code"`
+		RequestID string `json:"request_id,omitempty"`
+		*internal.APIError
+	}{Kind: kind, Message: err.Error(), ExitCode: code, RequestID: internal.RequestIDOf(err)}
+	errors.As(err, &out.APIError)
+	return out, code
 }
 
 func init() {
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &internal.UsageError{Err: err}
+	})
+
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key (overrides FTI_API_KEY env and ~/.fti/config.toml)")
+	rootCmd.PersistentFlags().StringVar(&internal.ProfileFlag, "profile", "", "Config profile to use (overrides FTI_PROFILE and default_profile)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Output raw JSON")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", internal.DefaultTimeout, "Time limit for each API request, including its retries")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", internal.DefaultRetries, "Retries for failed GET requests (network errors, 429, 5xx)")
+	rootCmd.PersistentFlags().DurationVar(&retryMaxWait, "retry-max-wait", internal.DefaultRetryMaxWait, "Longest wait between retries, including Retry-After")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Override how long cached responses stay fresh (0 = per-endpoint defaults)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Always fetch from the API (responses are still cached)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Serve the last stored responses without touching the network")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Log every HTTP request to stderr")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "Write all HTTP requests to a HAR file")
+	rootCmd.PersistentFlags().StringVar(&maxRespSize, "max-response-size", "32MB", "Largest decoded response body to accept, e.g. 512KB or 1GB (0 = no limit)")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Fail when a response doesn't match the schema fti was built for (default: warn once)")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "HTTP(S) or SOCKS5 proxy URL (default: HTTPS_PROXY / HTTP_PROXY env)")
+	rootCmd.PersistentFlags().StringVar(&caBundle, "ca-bundle", "", "PEM file of extra CA certificates to trust")
+	rootCmd.PersistentFlags().StringVar(&clientCert, "client-cert", "", "PEM client certificate for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientKey, "client-key", "", "PEM private key for --client-cert")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Disable TLS certificate verification (local testing only)")
 }
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 
 	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +25,13 @@ var (
 	signalsDays      int
 	signalsOutcome   string
 	signalsLimit     int
+
+	signalsAllPages   bool
+	signalsMaxRecords int
+
+	signalsWatch    bool
+	signalsInterval int
+	signalsNoStream bool
 )
 
 var signalsActiveCmd = &cobra.Command{
@@ -33,75 +43,146 @@ var signalsActiveCmd = &cobra.Command{
 			return err
 		}
 		if key == "" {
-			return fmt.Errorf("API key required — run: fti auth login")
+			return internal.ErrNoAPIKey
 		}
 
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, key)
-
-		params := map[string]string{
-			"min_confidence": fmt.Sprintf("%.2f", signalsMinConf),
-		}
-		if signalsToken != "" {
-			params["token"] = strings.ToUpper(signalsToken)
+		c, err := newClient(key)
+		if err != nil {
+			return err
 		}
-		q := buildQuery(params)
 
-		var resp struct {
-			ActiveSignals int `json:"active_signals"`
-			Signals       []struct {
-				ID                 string  `json:"id"`
-				Token              string  `json:"token"`
-				Direction          string  `json:"direction"`
-				Tier               string  `json:"tier"`
-				SellRatio          float64 `json:"sell_ratio"`
-				ConfidenceScore    float64 `json:"confidence_score"`
-				EntryPrice         float64 `json:"entry_price"`
-				TargetPrice        float64 `json:"target_price"`
-				StopPrice          float64 `json:"stop_price"`
-				CreatedAt          string  `json:"created_at"`
-				ExpiresAt          string  `json:"expires_at"`
-				PrimaryReason      string  `json:"primary_reason"`
-				MaxProfitPct       float64 `json:"max_profit_pct"`
-				TrailingStopStatus string  `json:"trailing_stop_status"`
-			} `json:"signals"`
+		ctx := cmd.Context()
+		if !signalsWatch {
+			return activeSignals(ctx, c.Client)
 		}
 
-		raw, err := c.Get("/api/v1/signals/active", q, &resp)
-		if err != nil {
+		// Watch mode: follow the live stream, or poll when the API has none.
+		if err := checkInterval(signalsInterval); err != nil {
 			return err
 		}
-
-		if jsonOut {
-			internal.PrintJSON(raw)
-			return nil
+		c.core.NoCache = true
+		if !signalsNoStream {
+			err := signalsStream(ctx, c.Client)
+			if !errors.Is(err, fti.ErrStreamUnsupported) {
+				return streamEnded(ctx, err)
+			}
+			streamFallback(signalsInterval)
 		}
+		return pollLoop(ctx, signalsInterval, func() error {
+			return activeSignals(ctx, c.Client)
+		})
+	},
+}
 
-		if resp.ActiveSignals == 0 {
-			internal.Dim.Println("\nNo active signals.")
-			return nil
-		}
+func activeSignals(ctx context.Context, c *fti.Client) error {
+	list, res, err := c.ActiveSignals(ctx, &fti.ActiveSignalsOptions{
+		Token:         strings.ToUpper(signalsToken),
+		MinConfidence: signalsMinConf,
+	})
+	if err != nil {
+		return err
+	}
 
-		internal.Bold.Printf("\n%d active signal(s)\n\n", resp.ActiveSignals)
-		t := internal.NewTable("TOKEN", "DIR", "TIER", "CONF", "ENTRY", "TARGET", "STOP", "MAX%", "EXPIRES")
-		t.Header()
-		for _, s := range resp.Signals {
-			t.Row(
-				internal.Cyan.Sprint(s.Token),
-				internal.FormatDirection(s.Direction),
-				tierColor(s.Tier),
-				internal.FormatConfidence(s.ConfidenceScore),
-				internal.FormatPrice(s.EntryPrice),
-				internal.FormatPrice(s.TargetPrice),
-				internal.FormatPrice(s.StopPrice),
-				fmt.Sprintf("%.1f%%", s.MaxProfitPct),
-				shortTime(s.ExpiresAt),
-			)
+	if jsonOut {
+		printJSON(res)
+		return nil
+	}
+	renderActiveSignals(list.Signals, res)
+	return nil
+}
+
+func renderActiveSignals(signals []fti.Signal, res *fti.Response) {
+	if len(signals) == 0 {
+		internal.Dim.Println("\nNo active signals.")
+		staleFooter(res)
+		return
+	}
+
+	internal.Bold.Printf("\n%d active signal(s)\n\n", len(signals))
+	t := internal.NewTable("TOKEN", "DIR", "TIER", "CONF", "ENTRY", "TARGET", "STOP", "MAX%", "EXPIRES")
+	t.Header()
+	for _, s := range signals {
+		t.Row(
+			internal.Cyan.Sprint(s.Token),
+			internal.FormatDirection(s.Direction),
+			tierColor(s.Tier),
+			internal.FormatConfidence(s.ConfidenceScore),
+			internal.FormatPrice(s.EntryPrice),
+			internal.FormatPrice(s.TargetPrice),
+			internal.FormatPrice(s.StopPrice),
+			fmt.Sprintf("%.1f%%", s.MaxProfitPct),
+			shortTime(s.ExpiresAt),
+		)
+	}
+	t.Flush()
+	staleFooter(res)
+	fmt.Println()
+}
+
+// signalsStream follows the live signal stream. With --json each change is
+// printed as one {"event", "signal"} JSON line; otherwise the table starts
+// from the current signals and is redrawn as they open, change and close.
+func signalsStream(ctx context.Context, c *fti.Client) error {
+	opts := &fti.SignalStreamOptions{
+		Token:         strings.ToUpper(signalsToken),
+		MinConfidence: signalsMinConf,
+		OnReconnect:   streamReconnect,
+	}
+	if jsonOut {
+		return c.SignalStream(ctx, opts, func(ev fti.SignalEvent) error {
+			return printEvent(map[string]interface{}{"event": ev.Type, "signal": ev.Signal})
+		})
+	}
+
+	// The current signals are fetched once the stream is up, so an API
+	// without a stream falls back to polling without a redundant fetch. If
+	// that fetch fails the stream is stopped and the error returned.
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	var live []fti.Signal
+	var res *fti.Response
+	var err error
+	draw := func() {
+		clearScreen()
+		renderActiveSignals(live, res)
+		internal.Dim.Println("\n  Live — Ctrl+C to stop")
+	}
+	opts.OnConnect = func() {
+		if res != nil {
+			return
 		}
-		t.Flush()
-		fmt.Println()
+		var list *fti.ActiveSignalList
+		list, res, err = c.ActiveSignals(ctx, &fti.ActiveSignalsOptions{
+			Token:         opts.Token,
+			MinConfidence: opts.MinConfidence,
+		})
+		if err != nil {
+			stop(err)
+			return
+		}
+		live = list.Signals
+		draw()
+	}
+	streamErr := c.SignalStream(ctx, opts, func(ev fti.SignalEvent) error {
+		i := slices.IndexFunc(live, func(s fti.Signal) bool { return s.ID == ev.Signal.ID })
+		switch {
+		case ev.Type == fti.SignalClosed:
+			if i < 0 {
+				return nil
+			}
+			live = slices.Delete(live, i, i+1)
+		case i >= 0:
+			live[i] = ev.Signal
+		default:
+			live = append(live, ev.Signal)
+		}
+		draw()
 		return nil
-	},
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
 }
 
 // ── signals history ──────────────────────────────────────────────────────────
@@ -115,79 +196,112 @@ var signalsHistoryCmd = &cobra.Command{
 			return err
 		}
 		if key == "" {
-			return fmt.Errorf("API key required — run: fti auth login")
+			return internal.ErrNoAPIKey
 		}
 
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, key)
-
-		params := map[string]string{
-			"days":  strconv.Itoa(signalsDays),
-			"limit": strconv.Itoa(signalsLimit),
-		}
-		if signalsToken != "" {
-			params["token"] = strings.ToUpper(signalsToken)
-		}
-		if signalsOutcome != "" {
-			params["outcome"] = signalsOutcome
+		c, err := newClient(key)
+		if err != nil {
+			return err
 		}
-		q := buildQuery(params)
 
-		var resp struct {
-			Signals []struct {
-				ID              string  `json:"id"`
-				Token           string  `json:"token"`
-				Tier            string  `json:"tier"`
-				SellRatio       float64 `json:"sell_ratio"`
-				ConfidenceScore float64 `json:"confidence_score"`
-				EntryPrice      float64 `json:"entry_price"`
-				TargetPrice     float64 `json:"target_price"`
-				StopPrice       float64 `json:"stop_price"`
-				OutcomeStatus   string  `json:"outcome_status"`
-				PnlPct          float64 `json:"pnl_pct"`
-				MaxProfitPct    float64 `json:"max_profit_pct"`
-				CreatedAt       string  `json:"created_at"`
-				ExitTime        string  `json:"exit_time"`
-				ExitPrice       float64 `json:"exit_price"`
-			} `json:"signals"`
+		opts := &fti.SignalHistoryOptions{
+			Token:   strings.ToUpper(signalsToken),
+			Days:    signalsDays,
+			Outcome: signalsOutcome,
+			Limit:   signalsLimit,
+		}
+		if signalsAllPages {
+			return signalHistoryPages(cmd.Context(), c.Client, opts)
 		}
 
-		raw, err := c.Get("/api/v1/signals/history", q, &resp)
+		signals, res, err := c.SignalHistory(cmd.Context(), opts)
 		if err != nil {
 			return err
 		}
 
 		if jsonOut {
-			internal.PrintJSON(raw)
+			printJSON(res)
 			return nil
 		}
 
-		if len(resp.Signals) == 0 {
+		if len(signals) == 0 {
 			internal.Dim.Println("\nNo signal history found.")
+			staleFooter(res)
 			return nil
 		}
 
-		internal.Bold.Printf("\n%d signal(s) — last %d days\n\n", len(resp.Signals), signalsDays)
-		t := internal.NewTable("TOKEN", "TIER", "CONF", "ENTRY", "EXIT", "PNL%", "OUTCOME", "DATE")
-		t.Header()
-		for _, s := range resp.Signals {
-			t.Row(
-				internal.Cyan.Sprint(s.Token),
-				tierColor(s.Tier),
-				internal.FormatConfidence(s.ConfidenceScore),
-				internal.FormatPrice(s.EntryPrice),
-				internal.FormatPrice(s.ExitPrice),
-				formatPnl(s.PnlPct),
-				internal.FormatOutcome(s.OutcomeStatus),
-				shortTime(s.CreatedAt),
-			)
+		internal.Bold.Printf("\n%d signal(s) — last %d days\n\n", len(signals), signalsDays)
+		t := newSignalHistoryTable()
+		for _, s := range signals {
+			signalHistoryRow(t, s)
 		}
 		t.Flush()
+		staleFooter(res)
 		fmt.Println()
 		return nil
 	},
 }
 
+// signalHistoryPages streams every page of history as NDJSON with --json.
+// Otherwise it prints one table once the last page is in.
+func signalHistoryPages(ctx context.Context, c *fti.Client, opts *fti.SignalHistoryOptions) error {
+	var t *internal.Table
+	var stale *fti.Response
+	n := 0
+	err := c.SignalHistoryPages(ctx, opts, signalsMaxRecords, func(page []fti.Signal, res *fti.Response) error {
+		n += len(page)
+		if jsonOut {
+			return printNDJSON(res, "signals", len(page))
+		}
+		if t == nil {
+			fmt.Println()
+			t = newSignalHistoryTable()
+		}
+		for _, s := range page {
+			signalHistoryRow(t, s)
+		}
+		if stale == nil && internal.StaleNotice(res.Meta) != "" {
+			stale = res
+		}
+		return nil
+	})
+	if t != nil {
+		t.Flush()
+	}
+	if err != nil || jsonOut {
+		return err
+	}
+	if n == 0 {
+		internal.Dim.Println("\nNo signal history found.")
+	} else {
+		internal.Bold.Printf("\n%d signal(s) — last %d days\n", n, signalsDays)
+	}
+	if stale != nil {
+		staleFooter(stale)
+	}
+	fmt.Println()
+	return nil
+}
+
+func newSignalHistoryTable() *internal.Table {
+	t := internal.NewTable("TOKEN", "TIER", "CONF", "ENTRY", "EXIT", "PNL%", "OUTCOME", "DATE")
+	t.Header()
+	return t
+}
+
+func signalHistoryRow(t *internal.Table, s fti.Signal) {
+	t.Row(
+		internal.Cyan.Sprint(s.Token),
+		tierColor(s.Tier),
+		internal.FormatConfidence(s.ConfidenceScore),
+		internal.FormatPrice(s.EntryPrice),
+		internal.FormatPrice(s.ExitPrice),
+		formatPnl(s.PnlPct),
+		internal.FormatOutcome(s.OutcomeStatus),
+		shortTime(s.CreatedAt),
+	)
+}
+
 func tierColor(tier string) string {
 	switch tier {
 	case "high":
@@ -213,11 +327,16 @@ func formatPnl(pct float64) string {
 func init() {
 	signalsActiveCmd.Flags().StringVar(&signalsToken, "token", "", "Filter by token symbol")
 	signalsActiveCmd.Flags().Float64Var(&signalsMinConf, "min-confidence", 0.65, "Minimum confidence (0-1)")
+	signalsActiveCmd.Flags().BoolVar(&signalsWatch, "watch", false, "Follow signals live as they open, change and close (polls if the API has no stream)")
+	signalsActiveCmd.Flags().IntVar(&signalsInterval, "interval", 30, "Refresh interval in seconds when polling (with --watch)")
+	signalsActiveCmd.Flags().BoolVar(&signalsNoStream, "no-stream", false, "Poll instead of using the live stream (with --watch)")
 
 	signalsHistoryCmd.Flags().StringVar(&signalsToken, "token", "", "Filter by token symbol")
 	signalsHistoryCmd.Flags().IntVar(&signalsDays, "days", 30, "Look-back period in days")
 	signalsHistoryCmd.Flags().StringVar(&signalsOutcome, "outcome", "", "Filter by outcome (target_hit, stopped_out, expired)")
-	signalsHistoryCmd.Flags().IntVar(&signalsLimit, "limit", 50, "Max results")
+	signalsHistoryCmd.Flags().IntVar(&signalsLimit, "limit", 50, "Max results (page size with --all-pages)")
+	signalsHistoryCmd.Flags().BoolVar(&signalsAllPages, "all-pages", false, "Follow pagination and stream every page (NDJSON with --json)")
+	signalsHistoryCmd.Flags().IntVar(&signalsMaxRecords, "max-records", defaultMaxRecords, "Stop --all-pages after this many records (0 = no limit)")
 
 	signalsCmd.AddCommand(signalsActiveCmd)
 	signalsCmd.AddCommand(signalsHistoryCmd)
@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
 	"github.com/spf13/cobra"
 )
 
@@ -23,42 +23,22 @@ var sportsUpcomingCmd = &cobra.Command{
 	Use:   "upcoming",
 	Short: "List upcoming matches with token context",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, "")
-
-		params := map[string]string{
-			"days":  strconv.Itoa(sportsDays),
-			"limit": "100",
-		}
-		if sportsToken != "" {
-			params["token"] = strings.ToUpper(sportsToken)
-		}
-		q := buildQuery(params)
-
-		var resp struct {
-			Count       int    `json:"count"`
-			Days        int    `json:"days"`
-			TokenFilter string `json:"token_filter"`
-			Matches     []struct {
-				MatchID         string  `json:"match_id"`
-				HomeTeam        string  `json:"home_team"`
-				AwayTeam        string  `json:"away_team"`
-				MatchDate       string  `json:"match_date"`
-				Competition     string  `json:"competition"`
-				Status          string  `json:"status"`
-				ImportanceScore float64 `json:"importance_score"`
-				HomeToken       string  `json:"home_token"`
-				AwayToken       string  `json:"away_token"`
-			} `json:"matches"`
+		c, err := newClient("")
+		if err != nil {
+			return err
 		}
 
-		raw, err := c.Get("/api/matches/upcoming", q, &resp)
+		matches, res, err := c.UpcomingMatches(cmd.Context(), &fti.UpcomingMatchesOptions{
+			Token: strings.ToUpper(sportsToken),
+			Days:  sportsDays,
+			Limit: 100,
+		})
 		if err != nil {
 			return err
 		}
 
 		if jsonOut {
-			internal.PrintJSON(raw)
+			printJSON(res)
 			return nil
 		}
 
@@ -69,14 +49,15 @@ var sportsUpcomingCmd = &cobra.Command{
 
 		internal.Bold.Printf("\nUpcoming matches — %s  (next %d days)\n\n", filter, sportsDays)
 
-		if resp.Count == 0 {
+		if len(matches) == 0 {
 			internal.Dim.Println("No upcoming matches found.")
+			staleFooter(res)
 			return nil
 		}
 
 		t := internal.NewTable("DATE", "HOME", "AWAY", "COMPETITION", "TOKENS", "IMP")
 		t.Header()
-		for _, m := range resp.Matches {
+		for _, m := range matches {
 			tokens := tokenPair(m.HomeToken, m.AwayToken)
 			t.Row(
 				shortTime(m.MatchDate),
@@ -88,7 +69,8 @@ var sportsUpcomingCmd = &cobra.Command{
 			)
 		}
 		t.Flush()
-		fmt.Printf("\n%d match(es)\n", resp.Count)
+		fmt.Printf("\n%d match(es)\n", len(matches))
+		staleFooter(res)
 		return nil
 	},
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+)
+
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
+
+// checkInterval validates --interval for watch mode.
+func checkInterval(interval int) error {
+	if interval < 1 {
+		return &internal.UsageError{Err: fmt.Errorf("--interval must be at least 1, got %d", interval)}
+	}
+	return nil
+}
+
+// pollLoop redraws the screen with draw every interval seconds until the
+// root context is cancelled (Ctrl+C). Errors are shown and polling goes on.
+func pollLoop(ctx context.Context, interval int, draw func() error) error {
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		clearScreen()
+		if err := draw(); err != nil && ctx.Err() == nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		internal.Dim.Printf("\n  Refreshing every %ds — Ctrl+C to stop\n", interval)
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamFallback tells the user a live stream is unavailable and watch mode
+// is polling instead.
+func streamFallback(interval int) {
+	fmt.Fprintln(os.Stderr, internal.Dim.Sprintf("Live stream unavailable, polling every %ds", interval))
+}
+
+// streamReconnect reports a dropped stream that is being resumed.
+func streamReconnect(err error, wait time.Duration) {
+	fmt.Fprintln(os.Stderr, internal.Yellow.Sprintf("stream interrupted (%v), reconnecting in %s", err, wait.Round(100*time.Millisecond)))
+}
+
+// printEvent writes one streamed event as a compact JSON line.
+func printEvent(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(line, '\n'))
+	return err
+}
+
+// streamEnded returns nil for a stream stopped by Ctrl+C, like pollLoop.
+func streamEnded(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		if !jsonOut {
+			fmt.Println()
+		}
+		return nil
+	}
+	return err
+}
@@ -1,15 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"strconv"
 	"strings"
-	"syscall"
-	"time"
 
 	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +18,10 @@ var (
 	whalesMinValue float64
 	whalesWatch    bool
 	whalesInterval int
+	whalesNoStream bool
+
+	whalesAllPages   bool
+	whalesMaxRecords int
 )
 
 var whalesCmd = &cobra.Command{
@@ -27,94 +29,67 @@ var whalesCmd = &cobra.Command{
 	Short: "CEX + DEX whale trade activity",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		baseURL := internal.ResolveBaseURL(defaultBaseURL)
-		c := internal.NewClient(baseURL, "")
+		c, err := newClient("")
+		if err != nil {
+			return err
+		}
 
 		symbol := ""
 		if !whalesAll && len(args) > 0 {
 			symbol = strings.ToUpper(args[0])
 		}
 
+		ctx := cmd.Context()
+		if whalesAllPages {
+			if whalesWatch {
+				return &internal.UsageError{Err: fmt.Errorf("--all-pages cannot be combined with --watch")}
+			}
+			return whalePages(ctx, c.Client, symbol)
+		}
 		if !whalesWatch {
-			return whalesCombined(c, symbol)
+			return whalesCombined(ctx, c.Client, symbol)
 		}
 
-		// Watch mode: poll on a ticker, clear screen between updates.
-		sig := make(chan os.Signal, 1)
-		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-
-		ticker := time.NewTicker(time.Duration(whalesInterval) * time.Second)
-		defer ticker.Stop()
-
-		clearScreen()
-		if err := whalesCombined(c, symbol); err != nil {
-			fmt.Fprintln(os.Stderr, "error:", err)
+		// Watch mode: follow the live stream, or poll when the API has none.
+		// The root context is cancelled on Ctrl+C. Every poll goes to the
+		// API so a short --interval never redraws a cached page.
+		if err := checkInterval(whalesInterval); err != nil {
+			return err
 		}
-		internal.Dim.Printf("\n  Refreshing every %ds — Ctrl+C to stop\n", whalesInterval)
-
-		for {
-			select {
-			case <-sig:
-				fmt.Println()
-				return nil
-			case <-ticker.C:
-				clearScreen()
-				if err := whalesCombined(c, symbol); err != nil {
-					fmt.Fprintln(os.Stderr, "error:", err)
-				}
-				internal.Dim.Printf("\n  Refreshing every %ds — Ctrl+C to stop\n", whalesInterval)
+		c.core.NoCache = true
+		if !whalesNoStream {
+			err := whalesStream(ctx, c.Client, symbol)
+			if !errors.Is(err, fti.ErrStreamUnsupported) {
+				return streamEnded(ctx, err)
 			}
+			streamFallback(whalesInterval)
 		}
+		return pollLoop(ctx, whalesInterval, func() error {
+			return whalesCombined(ctx, c.Client, symbol)
+		})
 	},
 }
 
-func clearScreen() {
-	fmt.Print("\033[H\033[2J")
-}
-
-type whaleTrade struct {
-	Time         string  `json:"time"`
-	Venue        string  `json:"venue"`
-	Symbol       string  `json:"symbol"`
-	Exchange     string  `json:"exchange"`
-	Side         string  `json:"side"`
-	Price        float64 `json:"price"`
-	Quantity     float64 `json:"quantity"`
-	ValueUSD     float64 `json:"value_usd"`
-	IsAggressive bool    `json:"is_aggressive"`
-	TxHash       string  `json:"tx_hash"`
-}
-
-func whalesCombined(c *internal.Client, symbol string) error {
-	params := map[string]string{
-		"limit":     strconv.Itoa(whalesLimit),
-		"min_value": fmt.Sprintf("%.0f", whalesMinValue),
-		"hours":     strconv.Itoa(whalesHours),
-	}
-	if symbol != "" {
-		params["symbol"] = symbol
-	}
-	q := buildQuery(params)
-
-	var resp struct {
-		Transactions []whaleTrade `json:"transactions"`
-		Count        int          `json:"count"`
-		CexCount     int          `json:"cex_count"`
-		DexCount     int          `json:"dex_count"`
-		Threshold    float64      `json:"threshold_usd"`
-		Timestamp    string       `json:"timestamp"`
-	}
-
-	raw, err := c.Get("/api/whales/combined", q, &resp)
+func whalesCombined(ctx context.Context, c *fti.Client, symbol string) error {
+	resp, res, err := c.Whales(ctx, &fti.WhalesOptions{
+		Symbol:   symbol,
+		Hours:    whalesHours,
+		Limit:    whalesLimit,
+		MinValue: whalesMinValue,
+	})
 	if err != nil {
 		return err
 	}
 
 	if jsonOut {
-		internal.PrintJSON(raw)
+		printJSON(res)
 		return nil
 	}
+	renderWhales(symbol, resp, res)
+	return nil
+}
 
+func renderWhales(symbol string, resp *fti.WhaleActivity, res *fti.Response) {
 	filter := "all tokens"
 	if symbol != "" {
 		filter = symbol
@@ -125,32 +100,161 @@ func whalesCombined(c *internal.Client, symbol string) error {
 
 	if resp.Count == 0 {
 		internal.Dim.Println("No whale trades found.")
-		return nil
+		staleFooter(res)
+		return
 	}
 
-	t := internal.NewTable("TIME", "VENUE", "TOKEN", "EXCHANGE", "SIDE", "PRICE", "QTY", "VALUE")
-	t.Header()
+	t := newWhaleTable()
 	for _, tr := range resp.Transactions {
-		aggressiveFlag := ""
-		if tr.IsAggressive {
-			aggressiveFlag = internal.Yellow.Sprint(" *")
-		}
-		t.Row(
-			internal.Dim.Sprint(shortTime(tr.Time)),
-			strings.ToUpper(tr.Venue),
-			internal.Cyan.Sprint(tr.Symbol),
-			tr.Exchange,
-			internal.FormatSide(tr.Side)+aggressiveFlag,
-			internal.FormatPrice(tr.Price),
-			formatQty(tr.Quantity),
-			internal.FormatVolume(tr.ValueUSD),
-		)
+		whaleRow(t, tr)
 	}
 	t.Flush()
 	fmt.Printf("\n%d trades  CEX:%d  DEX:%d  (*)=aggressive\n", resp.Count, resp.CexCount, resp.DexCount)
+	staleFooter(res)
+}
+
+// whalesStream follows the live trade stream. With --json each new trade
+// is printed as one JSON line; otherwise the table starts from the current
+// trades and is redrawn as new ones arrive, newest first.
+func whalesStream(ctx context.Context, c *fti.Client, symbol string) error {
+	opts := &fti.WhaleStreamOptions{
+		Symbol:      symbol,
+		MinValue:    whalesMinValue,
+		OnReconnect: streamReconnect,
+	}
+	if jsonOut {
+		return c.WhaleStream(ctx, opts, func(ev fti.WhaleEvent) error {
+			return printEvent(ev.Trade)
+		})
+	}
+
+	// The current trades are fetched once the stream is up, so an API
+	// without a stream falls back to polling without a redundant fetch. If
+	// that fetch fails the stream is stopped, rather than left waiting for
+	// an event before the error is reported.
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	var live *fti.WhaleActivity
+	var res *fti.Response
+	var err error
+	draw := func() {
+		clearScreen()
+		renderWhales(symbol, live, res)
+		internal.Dim.Println("\n  Live — Ctrl+C to stop")
+	}
+	opts.OnConnect = func() {
+		if live != nil {
+			return
+		}
+		live, res, err = c.Whales(ctx, &fti.WhalesOptions{
+			Symbol:   symbol,
+			Hours:    whalesHours,
+			Limit:    whalesLimit,
+			MinValue: whalesMinValue,
+		})
+		if err != nil {
+			stop(err)
+			return
+		}
+		draw()
+	}
+	streamErr := c.WhaleStream(ctx, opts, func(ev fti.WhaleEvent) error {
+		live.Transactions = append([]fti.WhaleTrade{ev.Trade}, live.Transactions...)
+		if whalesLimit > 0 && len(live.Transactions) > whalesLimit {
+			live.Transactions = live.Transactions[:whalesLimit]
+		}
+		live.Count, live.CexCount, live.DexCount = len(live.Transactions), 0, 0
+		for _, tr := range live.Transactions {
+			if tr.Venue == "dex" {
+				live.DexCount++
+			} else {
+				live.CexCount++
+			}
+		}
+		draw()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+// whalePages streams every page of trades as NDJSON with --json. Otherwise
+// it prints them as one table once the last page is in, so the columns
+// line up, with any stale-data notice after it.
+func whalePages(ctx context.Context, c *fti.Client, symbol string) error {
+	opts := &fti.WhalesOptions{
+		Symbol:   symbol,
+		Hours:    whalesHours,
+		Limit:    whalesLimit,
+		MinValue: whalesMinValue,
+	}
+	var t *internal.Table
+	var stale *fti.Response
+	n, cex, dex := 0, 0, 0
+	err := c.WhalePages(ctx, opts, whalesMaxRecords, func(page []fti.WhaleTrade, res *fti.Response) error {
+		n += len(page)
+		if jsonOut {
+			return printNDJSON(res, "transactions", len(page))
+		}
+		if t == nil {
+			fmt.Println()
+			t = newWhaleTable()
+		}
+		for _, tr := range page {
+			whaleRow(t, tr)
+			if tr.Venue == "dex" {
+				dex++
+			} else {
+				cex++
+			}
+		}
+		if stale == nil && internal.StaleNotice(res.Meta) != "" {
+			stale = res
+		}
+		return nil
+	})
+	if t != nil {
+		t.Flush()
+	}
+	if err != nil || jsonOut {
+		return err
+	}
+	if n == 0 {
+		internal.Dim.Println("\nNo whale trades found.")
+	} else {
+		fmt.Printf("\n%d trades  CEX:%d  DEX:%d  (*)=aggressive\n", n, cex, dex)
+	}
+	if stale != nil {
+		staleFooter(stale)
+	}
 	return nil
 }
 
+func newWhaleTable() *internal.Table {
+	t := internal.NewTable("TIME", "VENUE", "TOKEN", "EXCHANGE", "SIDE", "PRICE", "QTY", "VALUE")
+	t.Header()
+	return t
+}
+
+func whaleRow(t *internal.Table, tr fti.WhaleTrade) {
+	aggressiveFlag := ""
+	if tr.IsAggressive {
+		aggressiveFlag = internal.Yellow.Sprint(" *")
+	}
+	t.Row(
+		internal.Dim.Sprint(shortTime(tr.Time)),
+		strings.ToUpper(tr.Venue),
+		internal.Cyan.Sprint(tr.Symbol),
+		tr.Exchange,
+		internal.FormatSide(tr.Side)+aggressiveFlag,
+		internal.FormatPrice(tr.Price),
+		formatQty(tr.Quantity),
+		internal.FormatVolume(tr.ValueUSD),
+	)
+}
+
 func formatQty(q float64) string {
 	if q >= 1_000_000 {
 		return fmt.Sprintf("%.1fM", q/1_000_000)
@@ -164,10 +268,13 @@ func formatQty(q float64) string {
 func init() {
 	whalesCmd.Flags().BoolVar(&whalesAll, "all", false, "Show whales for all tokens")
 	whalesCmd.Flags().IntVar(&whalesHours, "hours", 24, "Look-back window in hours")
-	whalesCmd.Flags().IntVar(&whalesLimit, "limit", 50, "Max trades to show")
+	whalesCmd.Flags().IntVar(&whalesLimit, "limit", 50, "Max trades to show (page size with --all-pages)")
+	whalesCmd.Flags().BoolVar(&whalesAllPages, "all-pages", false, "Follow pagination and stream every page (NDJSON with --json)")
+	whalesCmd.Flags().IntVar(&whalesMaxRecords, "max-records", defaultMaxRecords, "Stop --all-pages after this many records (0 = no limit)")
 	whalesCmd.Flags().Float64Var(&whalesMinValue, "min-value", 50000, "Minimum trade value in USD")
-	whalesCmd.Flags().BoolVar(&whalesWatch, "watch", false, "Poll and refresh continuously")
-	whalesCmd.Flags().IntVar(&whalesInterval, "interval", 30, "Refresh interval in seconds (with --watch)")
+	whalesCmd.Flags().BoolVar(&whalesWatch, "watch", false, "Follow new trades live (polls if the API has no stream)")
+	whalesCmd.Flags().IntVar(&whalesInterval, "interval", 30, "Refresh interval in seconds when polling (with --watch)")
+	whalesCmd.Flags().BoolVar(&whalesNoStream, "no-stream", false, "Poll instead of using the live stream (with --watch)")
 
 	rootCmd.AddCommand(whalesCmd)
 }
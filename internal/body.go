@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxResponseSize bounds a decoded response body when no
+// --max-response-size is given.
+const DefaultMaxResponseSize = 32 << 20
+
+// readBody reads resp's body, decompressing gzip, with the decoded size
+// capped at c.MaxResponseSize. A declared Content-Length over the cap fails
+// before anything is read.
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	limit := c.MaxResponseSize
+	tooLarge := &ResponseTooLargeError{Method: resp.Request.Method, Endpoint: resp.Request.URL.Path, Limit: limit}
+
+	var r io.Reader = resp.Body
+	var buf bytes.Buffer
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing response: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else if n := resp.ContentLength; n > 0 {
+		if limit > 0 && n > limit {
+			return nil, tooLarge
+		}
+		buf.Grow(int(n))
+	}
+
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(buf.Len()) > limit {
+		return nil, tooLarge
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseSize parses a byte size such as "512KB", "32MB" or "1048576".
+// Units are binary (1KB = 1024 bytes).
+func ParseSize(s string) (int64, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(t, u.suffix) {
+			t, mult = strings.TrimSpace(strings.TrimSuffix(t, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(t, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 512KB, 32MB)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// FormatSize renders n bytes in the largest whole binary unit.
+func FormatSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
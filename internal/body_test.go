@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func bodyResponse(body io.Reader, length int64, encoding string) *http.Response {
+	req, _ := http.NewRequest("GET", "http://api.invalid/api/tokens", nil)
+	resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(body), ContentLength: length, Request: req}
+	if encoding != "" {
+		resp.Header.Set("Content-Encoding", encoding)
+	}
+	return resp
+}
+
+// unreadable fails the test if the body is read at all.
+type unreadable struct{ t *testing.T }
+
+func (u unreadable) Read([]byte) (int, error) {
+	u.t.Error("body read despite an oversized Content-Length")
+	return 0, io.EOF
+}
+
+func TestReadBodyGzip(t *testing.T) {
+	want := []byte(`[{"symbol":"PSG"}]`)
+	c := &Client{MaxResponseSize: 1024}
+	got, err := c.readBody(bodyResponse(bytes.NewReader(gzipped(t, want)), -1, "gzip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("readBody = %q, want %q", got, want)
+	}
+}
+
+func TestReadBodyContentLengthOverLimit(t *testing.T) {
+	c := &Client{MaxResponseSize: 1024}
+	_, err := c.readBody(bodyResponse(unreadable{t}, 4096, ""))
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+		t.Errorf("readBody = %v, want a *ResponseTooLargeError for 1024 bytes", err)
+	}
+}
+
+func TestReadBodyGzipDecodedLimit(t *testing.T) {
+	// 1MB of spaces compresses to about 1KB, under the limit; decoded it is far over.
+	data := gzipped(t, []byte(strings.Repeat(" ", 1<<20)))
+	c := &Client{MaxResponseSize: 64 << 10}
+	if int64(len(data)) >= c.MaxResponseSize {
+		t.Fatalf("compressed body is %d bytes, want it under the limit", len(data))
+	}
+	_, err := c.readBody(bodyResponse(bytes.NewReader(data), int64(len(data)), "gzip"))
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Errorf("readBody = %v, want a *ResponseTooLargeError", err)
+	}
+
+	// Exactly at the limit is fine.
+	data = gzipped(t, bytes.Repeat([]byte("x"), int(c.MaxResponseSize)))
+	if _, err := c.readBody(bodyResponse(bytes.NewReader(data), -1, "gzip")); err != nil {
+		t.Errorf("readBody at the limit = %v", err)
+	}
+}
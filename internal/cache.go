@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheTTLs lists how long GET responses stay fresh, by path prefix. The
+// first match wins; paths with no match are never cached. The token list
+// and token detail carry live prices and volumes alongside the metadata,
+// so they stay fresh for a minute only; slower data such as price history
+// and the match schedule is kept for longer.
+var cacheTTLs = []struct {
+	prefix string
+	ttl    time.Duration
+}{
+	{"/api/whales/", 10 * time.Second},
+	{"/api/v1/signals/", 30 * time.Second},
+	{"/api/tokens", time.Minute},
+	{"/api/history/price/", 5 * time.Minute},
+	{"/api/matches/", 15 * time.Minute},
+}
+
+// CacheTTL returns the default freshness window for path, or 0 if responses
+// from it are not cached.
+func CacheTTL(path string) time.Duration {
+	for _, t := range cacheTTLs {
+		if strings.HasPrefix(path, t.prefix) {
+			return t.ttl
+		}
+	}
+	return 0
+}
+
+// Cache stores GET responses on disk, one file per URL and API key.
+type Cache struct {
+	Dir string
+}
+
+type cacheEntry struct {
+	URL      string    `json:"url"`
+	Path     string    `json:"path"`
+	StoredAt time.Time `json:"stored_at"`
+	Body     []byte    `json:"body"`
+	Validators
+}
+
+// CacheStats summarises the cache directory.
+type CacheStats struct {
+	Entries int
+	Expired int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// NewCache returns the cache under ~/.fti/cache.
+func NewCache() (*Cache, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: filepath.Join(dir, "cache")}, nil
+}
+
+// cacheKey identifies a response by URL and the key that fetched it, since
+// signal endpoints answer differently per tier.
+func cacheKey(url, apiKey string) string {
+	sum := sha256.Sum256([]byte(keyFingerprint(apiKey) + " " + url))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) file(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Lookup returns the stored body for key if it is younger than ttl.
+func (c *Cache) Lookup(key string, ttl time.Duration) ([]byte, bool) {
+	e, err := c.read(c.file(key))
+	if err != nil || time.Since(e.StoredAt) > ttl {
+		return nil, false
+	}
+	return e.Body, true
+}
+
+// Latest returns the stored body for key regardless of age, with the time
+// it was fetched. It backs offline mode.
+func (c *Cache) Latest(key string) ([]byte, time.Time, bool) {
+	e, err := c.read(c.file(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return e.Body, e.StoredAt, true
+}
+
+// Conditional returns the stored body for key with the validators it was
+// served with, regardless of age. ok is false if there are no validators.
+func (c *Cache) Conditional(key string) ([]byte, Validators, bool) {
+	e, err := c.read(c.file(key))
+	if err != nil || e.Validators.IsZero() {
+		return nil, Validators{}, false
+	}
+	return e.Body, e.Validators, true
+}
+
+// Store saves body under key along with its validators. The write goes
+// through a temp file so concurrent readers never see a partial entry.
+func (c *Cache) Store(key, url, path string, body []byte, v Validators) error {
+	data, err := json.Marshal(cacheEntry{URL: url, Path: path, StoredAt: time.Now(), Body: body, Validators: v})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return fmt.Errorf("creating cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.file(key))
+}
+
+func (c *Cache) read(file string) (cacheEntry, error) {
+	var e cacheEntry
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return e, err
+	}
+	err = json.Unmarshal(data, &e)
+	return e, err
+}
+
+// Stats walks the cache directory. Expiry uses the default per-endpoint TTLs.
+func (c *Cache) Stats() (CacheStats, error) {
+	var st CacheStats
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return st, err
+	}
+	for _, f := range files {
+		e, err := c.read(f)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(f); err == nil {
+			st.Bytes += info.Size()
+		}
+		st.Entries++
+		if time.Since(e.StoredAt) > CacheTTL(e.Path) {
+			st.Expired++
+		}
+		if st.Oldest.IsZero() || e.StoredAt.Before(st.Oldest) {
+			st.Oldest = e.StoredAt
+		}
+		if e.StoredAt.After(st.Newest) {
+			st.Newest = e.StoredAt
+		}
+	}
+	return st, nil
+}
+
+// Clear removes every cached response and returns how many were deleted.
+func (c *Cache) Clear() (int, error) {
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
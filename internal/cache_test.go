@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		path     string
+		min, max time.Duration
+	}{
+		// Both carry live prices, which fti prices reads.
+		{"/api/tokens", time.Second, time.Minute},
+		{"/api/tokens/CHZ", time.Second, time.Minute},
+		{"/api/history/price/CHZ", time.Second, 15 * time.Minute},
+		{"/api/whales/combined", time.Second, time.Minute},
+		{"/api/v1/signals/active", time.Second, time.Minute},
+		{"/api/v1/auth/me", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := CacheTTL(tt.path); got < tt.min || got > tt.max {
+			t.Errorf("CacheTTL(%q) = %s, want between %s and %s", tt.path, got, tt.min, tt.max)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Cassette is an http.RoundTripper that records each request/response pair
+// to Dir, or with Replay set, serves them back without network access.
+// Requests are matched on method, path, query, body and conditional headers;
+// the host is ignored so a cassette recorded against one backend replays
+// against any base URL.
+type Cassette struct {
+	Dir    string
+	Replay bool
+	// APIKey is scrubbed from everything written to disk.
+	APIKey string
+	// Next performs real requests while recording. Defaults to http.DefaultTransport.
+	Next http.RoundTripper
+}
+
+// CassetteMissError is returned in replay mode for a request that was never
+// recorded. It is not retried and never falls back to cached data.
+type CassetteMissError struct {
+	Method string
+	URL    string
+	Dir    string
+}
+
+func (e *CassetteMissError) Error() string {
+	return fmt.Sprintf("replay: no recorded response for %s %s in %s (record it with FTI_RECORD)", e.Method, e.URL, e.Dir)
+}
+
+type cassetteEntry struct {
+	Request struct {
+		Method  string      `json:"method"`
+		URL     string      `json:"url"`
+		Headers http.Header `json:"headers"`
+		Body    string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status  int         `json:"status"`
+		Headers http.Header `json:"headers"`
+		Body    string      `json:"body"`
+	} `json:"response"`
+}
+
+// CassetteFromEnv returns a recording cassette for FTI_RECORD or a replaying
+// one for FTI_REPLAY, or nil if neither is set.
+func CassetteFromEnv(apiKey string) (*Cassette, error) {
+	rec, rep := os.Getenv("FTI_RECORD"), os.Getenv("FTI_REPLAY")
+	switch {
+	case rec != "" && rep != "":
+		return nil, errors.New("FTI_RECORD and FTI_REPLAY are mutually exclusive")
+	case rec != "":
+		if err := os.MkdirAll(rec, 0700); err != nil {
+			return nil, fmt.Errorf("creating cassette dir: %w", err)
+		}
+		return &Cassette{Dir: rec, APIKey: apiKey}, nil
+	case rep != "":
+		if _, err := os.Stat(rep); err != nil {
+			return nil, fmt.Errorf("FTI_REPLAY: %w", err)
+		}
+		return &Cassette{Dir: rep, Replay: true, APIKey: apiKey}, nil
+	}
+	return nil, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	target := req.URL.RequestURI()
+	file := filepath.Join(c.Dir, c.fileName(req, target, reqBody))
+
+	if c.Replay {
+		return c.replay(req, file, target)
+	}
+
+	next := c.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readPlain(resp)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+
+	var e cassetteEntry
+	e.Request.Method = req.Method
+	e.Request.URL = c.scrub(target)
+	e.Request.Headers = c.scrubHeaders(req.Header)
+	e.Request.Body = c.scrub(string(reqBody))
+	e.Response.Status = resp.StatusCode
+	e.Response.Headers = c.scrubHeaders(resp.Header)
+	e.Response.Body = c.scrub(string(respBody))
+
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		return nil, fmt.Errorf("recording %s: %w", file, err)
+	}
+	return resp, nil
+}
+
+// readPlain reads resp's body, decompressing gzip so cassettes stay
+// readable and diffable. The encoding headers are dropped to match.
+func readPlain(resp *http.Response) ([]byte, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.ReadAll(resp.Body)
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	return io.ReadAll(gz)
+}
+
+func (c *Cassette) replay(req *http.Request, file, target string) (*http.Response, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, &CassetteMissError{Method: req.Method, URL: target, Dir: c.Dir}
+	}
+	var e cassetteEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("replay: reading %s: %w", file, err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Response.Status, http.StatusText(e.Response.Status)),
+		StatusCode:    e.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Response.Headers,
+		Body:          io.NopCloser(strings.NewReader(e.Response.Body)),
+		ContentLength: int64(len(e.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// fileName derives a readable, collision-resistant name for a request. The
+// key is scrubbed first so recordings and replays with different keys match.
+// Conditional requests get their own file, so a recorded 304 never replaces
+// the full response it revalidated.
+func (c *Cassette) fileName(req *http.Request, target string, body []byte) string {
+	method := req.Method
+	target = c.scrub(target)
+	id := method + " " + target + "\n" + c.scrub(string(body))
+	for _, h := range []string{"If-None-Match", "If-Modified-Since"} {
+		if v := req.Header.Get(h); v != "" {
+			id += "\n" + h + ": " + v
+		}
+	}
+	sum := sha256.Sum256([]byte(id))
+
+	path := target
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	slug := strings.Trim(nonSlug.ReplaceAllString(path, "_"), "_")
+	return fmt.Sprintf("%s_%s_%s.json", strings.ToLower(method), slug, hex.EncodeToString(sum[:6]))
+}
+
+var (
+	nonSlug     = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	apiKeyField = regexp.MustCompile(`("api_key"\s*:\s*)"[^"]*"`)
+	apiKeyParam = regexp.MustCompile(`([?&](?:api_key|apikey|key)=)[^&#\s]*`)
+)
+
+const redacted = "REDACTED"
+
+// scrub removes the API key from s, including any "api_key" JSON field
+// (e.g. in the auth register response).
+func (c *Cassette) scrub(s string) string {
+	return scrubKey(s, c.APIKey)
+}
+
+// scrubKey removes apiKey from s, and any "api_key" JSON field or key query
+// parameter.
+func scrubKey(s, apiKey string) string {
+	if apiKey != "" {
+		s = strings.ReplaceAll(s, apiKey, redacted)
+	}
+	s = apiKeyParam.ReplaceAllString(s, "${1}"+redacted)
+	return apiKeyField.ReplaceAllString(s, `$1"`+redacted+`"`)
+}
+
+func (c *Cassette) scrubHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+redacted)
+	}
+	out.Del("Set-Cookie")
+	out.Del("Cookie")
+	for k, vs := range out {
+		for i, v := range vs {
+			vs[i] = c.scrub(v)
+		}
+		out[k] = vs
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const cassetteKey = "ti_secret_cassette_key"
+
+// countingTransport counts round trips made through Next.
+type countingTransport struct {
+	n    atomic.Int32
+	Next http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n.Add(1)
+	return t.Next.RoundTrip(req)
+}
+
+// cassetteFiles returns the contents of every recording in dir.
+func cassetteFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, string(data))
+	}
+	return files
+}
+
+func TestCassetteScrubsKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo-Key", strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"api_key":"` + cassetteKey + `","tier":"pro"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL, cassetteKey)
+	c.HTTPClient = &http.Client{Transport: &Cassette{Dir: dir, APIKey: cassetteKey}}
+	_, err := c.Post(context.Background(), "/api/test?api_key="+cassetteKey, map[string]string{"key": cassetteKey}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := cassetteFiles(t, dir)
+	if len(files) != 1 {
+		t.Fatalf("%d recordings, want 1", len(files))
+	}
+	if strings.Contains(files[0], cassetteKey) {
+		t.Errorf("recording contains the API key:\n%s", files[0])
+	}
+	for _, want := range []string{
+		`"url": "/api/test?api_key=REDACTED"`,
+		`"Bearer REDACTED"`,
+		`"X-Echo-Key": [`,
+		`\"api_key\":\"REDACTED\"`,
+	} {
+		if !strings.Contains(files[0], want) {
+			t.Errorf("recording lacks %s:\n%s", want, files[0])
+		}
+	}
+}
+
+func TestCassetteMissNotRetried(t *testing.T) {
+	dir := t.TempDir()
+	transport := &countingTransport{Next: &Cassette{Dir: dir, Replay: true}}
+	c := NewClient("http://api.invalid", "")
+	c.HTTPClient = &http.Client{Transport: transport}
+	c.Retries = 3
+	c.RetryMaxWait = 10 * time.Millisecond
+	c.Cache = &Cache{Dir: t.TempDir()}
+	c.NoCache = true
+
+	// A stored response must not stand in for the missing recording.
+	endpoint := c.BaseURL + "/api/tokens"
+	if err := c.Cache.Store(cacheKey(endpoint, ""), endpoint, "/api/tokens", []byte(`[]`), Validators{}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.Get(context.Background(), "/api/tokens", nil, nil)
+	var miss *CassetteMissError
+	if !errors.As(err, &miss) {
+		t.Fatalf("Get = %v, %v; want a *CassetteMissError", res, err)
+	}
+	if miss.URL != "/api/tokens" {
+		t.Errorf("miss.URL = %q", miss.URL)
+	}
+	if n := transport.n.Load(); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestCassetteKeepsFullResponseOnRevalidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"n":1}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL, "")
+	c.HTTPClient = &http.Client{Transport: &Cassette{Dir: dir}}
+	for i := 0; i < 2; i++ {
+		res, err := c.Get(context.Background(), "/api/test", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(res.Body) != `{"n":1}` {
+			t.Fatalf("request %d: body %q", i+1, res.Body)
+		}
+	}
+	if files := cassetteFiles(t, dir); len(files) != 2 {
+		t.Fatalf("%d recordings, want the full response and the 304", len(files))
+	}
+
+	// An unconditional replay still finds the full response.
+	r := NewClient("http://api.invalid", "")
+	r.HTTPClient = &http.Client{Transport: &Cassette{Dir: dir, Replay: true}}
+	res, err := r.Get(context.Background(), "/api/test", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Body) != `{"n":1}` {
+		t.Errorf("replayed body %q, want the full response", res.Body)
+	}
+}
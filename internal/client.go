@@ -2,111 +2,384 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
+// DefaultTimeout bounds a request, retries included, when no --timeout is
+// given.
+const DefaultTimeout = 30 * time.Second
+
 // Client wraps the Fan Token Intel REST API.
 type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// UserAgent is sent with every request. NewClient sets UserAgent("dev").
+	UserAgent string
+
+	// Endpoints, when it lists more than one URL, spreads requests over
+	// mirrors of the API: BaseURL is the first entry and requests fail over
+	// to the others while it is down. Cache keys always use BaseURL.
+	Endpoints *Endpoints
+
+	// Timeout bounds each call as a whole: every attempt, the waits between
+	// them and any rate-limit wait. Cancellation of the caller's context
+	// aborts the request regardless.
+	Timeout time.Duration
+
+	// Retries is the number of extra attempts made for idempotent requests
+	// after a network error, 429 or 5xx. Non-idempotent requests are sent once.
+	Retries int
+	// RetryMaxWait caps the delay between attempts. A Retry-After longer than
+	// this ends the retry loop instead of being shortened.
+	RetryMaxWait time.Duration
+
+	// Limiter, when set, holds requests back to stay within the key's quota.
+	Limiter *RateLimiter
+
+	// Cache, when set, serves fresh GET responses from disk. CacheTTL, if
+	// positive, replaces the per-endpoint freshness windows. NoCache skips
+	// lookups but still stores what is fetched.
+	Cache    *Cache
+	CacheTTL time.Duration
+	NoCache  bool
+
+	// Offline serves GETs from the cache regardless of age and never touches
+	// the network. Without it, a network failure falls back to the cache too.
+	Offline bool
+
+	// Tracer, when set, sees every request attempt (--verbose, --trace-file).
+	Tracer *Tracer
+
+	// MaxResponseSize caps a decoded response body in bytes; 0 disables the cap.
+	MaxResponseSize int64
+
+	// Strict fails requests whose response does not match the expected
+	// schema. Otherwise a mismatch is passed to OnSchemaDrift, if set, and
+	// the response is used as is.
+	Strict        bool
+	OnSchemaDrift func(*SchemaError)
+
+	// cond holds the last body and validators per GET so repeated calls
+	// (e.g. whales --watch) can be answered with 304 Not Modified.
+	condMu sync.Mutex
+	cond   map[string]revalidation
+}
+
+// reply is what the client keeps of an HTTP response.
+type reply struct {
+	status    int
+	header    http.Header
+	body      []byte
+	requestID string
+}
+
+// Response is a successful API response.
+type Response struct {
+	Body []byte
+	Meta Meta
+}
+
+// Meta describes where a response came from. It is surfaced as "_meta" in
+// JSON output.
+type Meta struct {
+	// StaleSince is when a response served from the cache past its TTL was
+	// originally fetched; StaleReason says why the API was not used.
+	StaleSince  *time.Time `json:"stale_since,omitempty"`
+	StaleReason string     `json:"stale_reason,omitempty"`
+
+	// RequestID is the X-Request-ID of the request that fetched the data,
+	// for quoting to the API operators.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// IsZero reports whether m carries no information.
+func (m Meta) IsZero() bool {
+	return m == Meta{}
 }
 
 // NewClient creates a Client. apiKey may be empty for public endpoints.
 func NewClient(baseURL, apiKey string) *Client {
 	return &Client{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		BaseURL:         baseURL,
+		APIKey:          apiKey,
+		HTTPClient:      &http.Client{},
+		UserAgent:       UserAgent("dev"),
+		Timeout:         DefaultTimeout,
+		Retries:         DefaultRetries,
+		RetryMaxWait:    DefaultRetryMaxWait,
+		MaxResponseSize: DefaultMaxResponseSize,
 	}
 }
 
-func (c *Client) do(req *http.Request) ([]byte, error) {
-	if c.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	}
+// do sends req, retrying transient failures, within c.Timeout. All
+// attempts share one X-Request-ID, which is recorded on the reply and on
+// any error returned.
+func (c *Client) do(req *http.Request) (*reply, error) {
+	id := c.setClientHeaders(req)
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "fti-cli/1.0")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	parent := req.Context()
+	if c.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(parent, c.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	rep, err := c.retry(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+			err = &NetworkError{
+				Method:   req.Method,
+				Endpoint: req.URL.Path,
+				Err:      fmt.Errorf("timed out after %s: %w", c.Timeout, context.DeadlineExceeded),
+			}
+		}
+		return nil, withRequestID(err, id)
+	}
+	rep.requestID = id
+	return rep, nil
+}
 
+// retry performs up to 1+c.Retries attempts of an idempotent req.
+func (c *Client) retry(req *http.Request) (*reply, error) {
+	attempts := 1
+	if isIdempotent(req.Method) {
+		attempts += c.Retries
+	}
+
+	for n := 0; ; n++ {
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		rep, err := c.sendFailover(req)
+		var rerr *retryableError
+		if !errors.As(err, &rerr) {
+			return rep, err
+		}
+		if n+1 >= attempts {
+			return nil, rerr.err
+		}
+
+		wait := rerr.retryAfter
+		if wait == 0 {
+			wait = backoff(n, c.RetryMaxWait)
+		}
+		if wait > c.RetryMaxWait {
+			return nil, rerr.err
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return nil, rerr.err // no time left to try again
+		}
+		if err := sleepCtx(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single round trip. Transient failures are returned as
+// *retryableError so do can decide whether to try again.
+func (c *Client) send(req *http.Request) (*reply, error) {
+	var reqBody []byte
+	if c.Tracer != nil && req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(rc)
+		}
+	}
+
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.Tracer.record(req, reqBody, nil, nil, start, err)
+		var miss *CassetteMissError
+		if errors.As(err, &miss) {
+			return nil, miss
+		}
+		if req.Context().Err() != nil {
+			return nil, req.Context().Err()
+		}
+		return nil, &retryableError{err: &NetworkError{Method: req.Method, Endpoint: req.URL.Path, Err: err}}
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if c.Limiter != nil {
+		c.Limiter.Observe(resp.Header) //nolint:errcheck
+	}
+
+	body, err := c.readBody(resp)
+	c.Tracer.record(req, reqBody, resp, body, start, err)
+	var tooLarge *ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return nil, tooLarge
+	}
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		if req.Context().Err() != nil {
+			return nil, req.Context().Err()
+		}
+		return nil, &retryableError{err: &NetworkError{
+			Method:   req.Method,
+			Endpoint: req.URL.Path,
+			Err:      fmt.Errorf("reading response: %w", err),
+		}}
 	}
 
 	if resp.StatusCode >= 400 {
-		var apiErr struct {
-			Detail string `json:"detail"`
-		}
-		if e := json.Unmarshal(body, &apiErr); e == nil && apiErr.Detail != "" {
-			return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, apiErr.Detail)
+		err := newAPIError(resp, body)
+		if isRetryableStatus(resp.StatusCode) {
+			return nil, &retryableError{
+				err:        err,
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			}
 		}
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, err
 	}
 
-	return body, nil
+	return &reply{status: resp.StatusCode, header: resp.Header, body: body}, nil
 }
 
 // Get performs a GET request. If out is non-nil the body is JSON-decoded into it.
-// The raw body bytes are always returned.
-func (c *Client) Get(path string, params url.Values, out interface{}) ([]byte, error) {
+// The raw body bytes are always returned in the Response.
+func (c *Client) Get(ctx context.Context, path string, params url.Values, out interface{}) (*Response, error) {
 	endpoint := c.BaseURL + path
 	if len(params) > 0 {
 		endpoint += "?" + params.Encode()
 	}
 
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
+	ttl := CacheTTL(path)
+	if ttl > 0 && c.CacheTTL > 0 {
+		ttl = c.CacheTTL
 	}
+	useCache := c.Cache != nil && ttl > 0
+	key := cacheKey(endpoint, c.APIKey)
 
-	body, err := c.do(req)
-	if err != nil {
-		return nil, err
+	res := &Response{}
+	switch {
+	case c.Offline:
+		if err := c.stale(res, key, "offline"); err != nil {
+			return nil, &NetworkError{Method: "GET", Endpoint: path, Err: err}
+		}
+	case useCache && !c.NoCache:
+		if res.Body, _ = c.Cache.Lookup(key, ttl); res.Body != nil {
+			c.Tracer.Notef("cache hit GET %s (ttl %s)", endpoint, ttl)
+		}
 	}
 
+	if res.Body == nil {
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		prev, conditional := c.previous(key, useCache)
+		if conditional {
+			prev.validators.apply(req)
+		}
+		rep, err := c.do(req)
+		var netErr *NetworkError
+		switch {
+		case errors.As(err, &netErr) && c.stale(res, key, "api unreachable") == nil:
+			// Serve the last known data instead of failing.
+		case err != nil:
+			return nil, err
+		default:
+			res.Body = rep.body
+			res.Meta.RequestID = rep.requestID
+			v := validatorsFrom(rep.header)
+			if rep.status == http.StatusNotModified {
+				if !conditional {
+					return nil, &APIError{StatusCode: rep.status, Detail: "unexpected 304 for unconditional request", Method: "GET", Endpoint: path}
+				}
+				res.Body = prev.body
+				v = v.merge(prev.validators)
+				c.Tracer.Notef("not modified GET %s, reusing stored body", endpoint)
+			}
+			c.remember(key, res.Body, v)
+			if useCache {
+				c.Cache.Store(key, endpoint, path, res.Body, v) //nolint:errcheck
+			}
+		}
+	}
+
+	if err := c.checkSchema("GET", path, res.Body, res.Meta.RequestID); err != nil {
+		return nil, err
+	}
 	if out != nil {
-		if err := json.Unmarshal(body, out); err != nil {
+		if err := json.Unmarshal(res.Body, out); err != nil {
 			return nil, fmt.Errorf("parsing response: %w", err)
 		}
 	}
-	return body, nil
+	return res, nil
+}
+
+// checkSchema validates a response body, failing only in strict mode.
+func (c *Client) checkSchema(method, path string, body []byte, requestID string) error {
+	serr := ValidateResponse(method, path, body)
+	if serr == nil {
+		return nil
+	}
+	serr.RequestID = requestID
+	switch {
+	case c.Strict:
+		return serr
+	case c.OnSchemaDrift != nil:
+		c.OnSchemaDrift(serr)
+	}
+	return nil
 }
 
-// Post performs a POST request with a JSON payload.
-func (c *Client) Post(path string, payload interface{}, out interface{}) ([]byte, error) {
+// stale fills res with the most recent stored response for key, whatever
+// its age, marking it stale for reason.
+func (c *Client) stale(res *Response, key, reason string) error {
+	if c.Cache == nil {
+		return errors.New("offline and cache disabled")
+	}
+	body, storedAt, ok := c.Cache.Latest(key)
+	if !ok {
+		return errors.New("offline and no stored response")
+	}
+	res.Body = body
+	res.Meta.StaleSince = &storedAt
+	res.Meta.StaleReason = reason
+	c.Tracer.Notef("serving stored response from %s (%s)", storedAt.Format(time.RFC3339), reason)
+	return nil
+}
+
+// Post performs a POST request with a JSON payload. POSTs always go to the
+// API, even in offline mode.
+func (c *Client) Post(ctx context.Context, path string, payload interface{}, out interface{}) (*Response, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.BaseURL+path, bytes.NewReader(data))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+path, bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	body, err := c.do(req)
+	rep, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkSchema("POST", path, rep.body, rep.requestID); err != nil {
+		return nil, err
+	}
 
 	if out != nil {
-		if err := json.Unmarshal(body, out); err != nil {
+		if err := json.Unmarshal(rep.body, out); err != nil {
 			return nil, fmt.Errorf("parsing response: %w", err)
 		}
 	}
-	return body, nil
+	return &Response{Body: rep.body, Meta: Meta{RequestID: rep.requestID}}, nil
 }
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimeoutCoversRetries(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	c.Timeout = 300 * time.Millisecond
+	c.Retries = 10
+	c.RetryMaxWait = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := c.Get(context.Background(), "/api/tokens", nil, nil)
+	elapsed := time.Since(start)
+	if err == nil {
+		t.Fatal("want an error")
+	}
+	if elapsed > 600*time.Millisecond {
+		t.Errorf("gave up after %s, want about the 300ms timeout", elapsed)
+	}
+	if n := attempts.Load(); n < 2 || n > 4 {
+		t.Errorf("%d attempts in 300ms of 100ms requests", n)
+	}
+	if code, kind := Classify(err); code != ExitNetwork && code != ExitServer {
+		t.Errorf("Classify(%v) = %d %s", err, code, kind)
+	}
+}
+
+func TestTimeoutOnHungRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	c.Timeout = 100 * time.Millisecond
+	_, err := c.Get(context.Background(), "/api/tokens", nil, nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get = %v, want a timeout", err)
+	}
+	if code, _ := Classify(err); code != ExitNetwork {
+		t.Errorf("exit code = %d, want %d", code, ExitNetwork)
+	}
+}
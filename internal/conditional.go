@@ -0,0 +1,79 @@
+package internal
+
+import "net/http"
+
+// Validators are the HTTP cache validators a response was served with. They
+// are sent back as If-None-Match / If-Modified-Since so the API can answer
+// 304 Not Modified instead of repeating an unchanged body.
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// IsZero reports whether v holds no validator.
+func (v Validators) IsZero() bool {
+	return v == Validators{}
+}
+
+func validatorsFrom(h http.Header) Validators {
+	return Validators{ETag: h.Get("ETag"), LastModified: h.Get("Last-Modified")}
+}
+
+// apply makes req conditional on v.
+func (v Validators) apply(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// merge keeps validators from prev that a 304 did not repeat.
+func (v Validators) merge(prev Validators) Validators {
+	if v.ETag == "" {
+		v.ETag = prev.ETag
+	}
+	if v.LastModified == "" {
+		v.LastModified = prev.LastModified
+	}
+	return v
+}
+
+// revalidation is a body the client can reuse if the API answers 304.
+type revalidation struct {
+	body       []byte
+	validators Validators
+}
+
+// previous returns the last body seen for key along with its validators:
+// from this process first (so polling works without a cache), then from
+// the disk cache when useCache is set.
+func (c *Client) previous(key string, useCache bool) (revalidation, bool) {
+	c.condMu.Lock()
+	r, ok := c.cond[key]
+	c.condMu.Unlock()
+	if ok {
+		return r, true
+	}
+	if useCache {
+		if body, v, ok := c.Cache.Conditional(key); ok {
+			return revalidation{body: body, validators: v}, true
+		}
+	}
+	return revalidation{}, false
+}
+
+// remember keeps body for revalidating key later in this process.
+func (c *Client) remember(key string, body []byte, v Validators) {
+	c.condMu.Lock()
+	defer c.condMu.Unlock()
+	if v.IsZero() {
+		delete(c.cond, key)
+		return
+	}
+	if c.cond == nil {
+		c.cond = make(map[string]revalidation)
+	}
+	c.cond[key] = revalidation{body: body, validators: v}
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotModifiedReusesStoredBody(t *testing.T) {
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			// A 304 may repeat only some validators.
+			w.Header().Set("ETag", `"v2"`)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 12 Oct 2026 10:00:00 GMT")
+		w.Write([]byte(`[{"symbol":"PSG"}]`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	cache := &Cache{Dir: t.TempDir()}
+	get := func() *Response {
+		t.Helper()
+		// A fresh client each time, so the body has to come from the cache.
+		c := NewClient(srv.URL, "")
+		c.Cache = cache
+		c.NoCache = true
+		res, err := c.Get(context.Background(), "/api/tokens", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	get()
+	res := get()
+	if string(res.Body) != `[{"symbol":"PSG"}]` {
+		t.Errorf("body after 304 = %q, want the stored body", res.Body)
+	}
+	if len(conditional) != 2 || conditional[0] != "" || conditional[1] != `"v1"` {
+		t.Errorf("If-None-Match sent = %q, want none then \"v1\"", conditional)
+	}
+
+	key := cacheKey(srv.URL+"/api/tokens", "")
+	body, v, ok := cache.Conditional(key)
+	if !ok || string(body) != `[{"symbol":"PSG"}]` {
+		t.Fatalf("cache.Conditional = %q, %v", body, ok)
+	}
+	want := Validators{ETag: `"v2"`, LastModified: "Mon, 12 Oct 2026 10:00:00 GMT"}
+	if v != want {
+		t.Errorf("stored validators = %+v, want %+v", v, want)
+	}
+}
+
+func TestUnexpectedNotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	res, err := c.Get(context.Background(), "/api/tokens", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotModified {
+		t.Fatalf("Get = %v, %v; want a 304 *APIError", res, err)
+	}
+}
@@ -4,62 +4,268 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 )
 
 // Config holds persisted CLI settings.
 type Config struct {
+	// Version is the layout the file was written in; see ConfigVersion.
+	Version int `toml:"version"`
+
 	APIKey  string `toml:"api_key"`
 	APIURL  string `toml:"api_url"`
+
+	// APIKeyCmd is a shell command that prints the API key, e.g. a password
+	// manager lookup. APIKeyStore keeps the key in the OS keyring or an
+	// encrypted file instead ("keyring" or "file"). Either replaces APIKey.
+	APIKeyCmd   string `toml:"api_key_cmd,omitempty"`
+	APIKeyStore string `toml:"api_key_store,omitempty"`
+
+	// APIURLs lists API mirrors in failover order. When set it replaces
+	// APIURL. EndpointCooldown is how long a failed mirror is skipped.
+	APIURLs          []string `toml:"api_urls,omitempty"`
+	EndpointCooldown string   `toml:"endpoint_cooldown,omitempty"`
+
+	// Retries and RetryMaxWait tune automatic retries of GET requests.
+	// RetryMaxWait is a Go duration string such as "30s".
+	Retries      *int   `toml:"retries,omitempty"`
+	RetryMaxWait string `toml:"retry_max_wait,omitempty"`
+
+	// Timeout bounds each request, retries included, as a Go duration string.
+	Timeout string `toml:"timeout,omitempty"`
+
+	// MaxResponseSize caps decoded response bodies, as a size such as "32MB".
+	MaxResponseSize string `toml:"max_response_size,omitempty"`
+
+	// Strict fails commands on responses that don't match the expected schema.
+	Strict bool `toml:"strict,omitempty"`
+
+	// Proxy, CABundle, ClientCert, ClientKey and InsecureSkipVerify
+	// configure the HTTP transport; see TransportOptions.
+	Proxy              string `toml:"proxy,omitempty"`
+	CABundle           string `toml:"ca_bundle,omitempty"`
+	ClientCert         string `toml:"client_cert,omitempty"`
+	ClientKey          string `toml:"client_key,omitempty"`
+	InsecureSkipVerify bool   `toml:"insecure_skip_verify,omitempty"`
+
+	// DefaultProfile names the profile used when neither --profile nor
+	// FTI_PROFILE selects one. Profiles are keyed by name.
+	DefaultProfile string             `toml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `toml:"profiles,omitempty"`
+
+	// TrustedProjects lists directories whose .fti.toml may set the API
+	// URL. It is only read from the user's own config.
+	TrustedProjects []string `toml:"trusted_projects,omitempty"`
+
+	// Defaults overrides command flag defaults: [defaults] for global flags,
+	// [defaults.whales] or [defaults.signals.active] for a command's own.
+	// Tables name subcommands; other values are flag values.
+	Defaults map[string]interface{} `toml:"defaults,omitempty"`
+
+	// Profile is the profile LoadConfig applied, if any, and ProjectFile the
+	// .fti.toml. Neither is stored.
+	Profile     string `toml:"-"`
+	ProjectFile string `toml:"-"`
+
+	projectKeys map[string]bool // keys set by ProjectFile
 }
 
-func configPath() (string, error) {
-	home, err := os.UserHomeDir()
+// ConfigPath returns the location of config.toml: ~/.fti/config.toml
+// unless FTI_HOME or the XDG base directories say otherwise (see ftiDirs).
+func ConfigPath() (string, error) {
+	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".fti", "config.toml"), nil
+	return filepath.Join(dir, "config.toml"), nil
 }
 
-// LoadConfig reads ~/.fti/config.toml. Missing file returns empty Config, no error.
+// LoadConfig reads ~/.fti/config.toml, applies the active profile (see
+// ActiveProfile) and then the project's .fti.toml, if any (see
+// applyProject). Missing files are empty, no error.
 func LoadConfig() (Config, error) {
-	path, err := configPath()
+	cfg, err := ReadConfigFile()
+	if err != nil {
+		return Config{}, err
+	}
+	proj, err := readProjectConfig()
 	if err != nil {
 		return Config{}, err
 	}
+	if proj != nil && proj.md.IsDefined("default_profile") {
+		cfg.DefaultProfile = proj.cfg.DefaultProfile
+	}
+	if cfg, err = applyProfile(cfg); err != nil {
+		return cfg, err
+	}
+	return applyProject(cfg, proj), nil
+}
 
+// ReadConfigFile reads ~/.fti/config.toml as written, without applying a
+// profile. Use it for changes saved with SaveConfig.
+func ReadConfigFile() (Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+	warnConfigMode(path)
+	cfg, _, err := readConfig(path)
+	return cfg, err
+}
+
+// CheckConfigFile parses ~/.fti/config.toml and returns any keys in it
+// that fti does not know, e.g. misspelled ones.
+func CheckConfigFile() ([]string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	_, md, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for _, k := range md.Undecoded() {
+		unknown = append(unknown, k.String())
+	}
+	return unknown, nil
+}
+
+// readConfig decodes the config file at path. A missing file is empty.
+func readConfig(path string) (Config, toml.MetaData, error) {
 	var cfg Config
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return Config{}, nil
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Config{}, toml.MetaData{}, nil
+	}
+	if err != nil {
+		return Config{}, toml.MetaData{}, fmt.Errorf("reading config: %w", err)
+	}
+	md, err := decodeConfig(string(data), &cfg)
+	if err != nil {
+		return Config{}, toml.MetaData{}, fmt.Errorf("reading config: %w", err)
+	}
+	return cfg, md, nil
+}
+
+// warnConfigMode warns when the config file can be read by other users.
+// It may hold the API key.
+func warnConfigMode(path string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&0044 == 0 {
+		return
 	}
-	if _, err := toml.DecodeFile(path, &cfg); err != nil {
-		return Config{}, fmt.Errorf("reading config: %w", err)
+	warnOnce("mode:"+path, "%s is readable by other users (mode %04o); run: chmod 600 %s",
+		path, info.Mode().Perm(), path)
+}
+
+var (
+	warnedMu sync.Mutex
+	warned   = map[string]bool{}
+)
+
+// warnOnce prints a warning to stderr the first time id is seen in a run.
+func warnOnce(id, format string, args ...interface{}) {
+	warnedMu.Lock()
+	defer warnedMu.Unlock()
+	if warned[id] {
+		return
 	}
-	return cfg, nil
+	warned[id] = true
+	fmt.Fprintf(os.Stderr, "%s: %s\n", Yellow.Sprint("warning"), fmt.Sprintf(format, args...))
 }
 
-// SaveConfig writes cfg to ~/.fti/config.toml, creating the directory if needed.
+// SaveConfig writes cfg to ~/.fti/config.toml, creating the directory if
+// needed. See UpdateConfig for read-modify-write changes.
 func SaveConfig(cfg Config) error {
-	path, err := configPath()
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(configLockPath(path))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeConfig(path, cfg)
+}
+
+// UpdateConfig reads ~/.fti/config.toml (without a profile applied), lets
+// fn change it and writes it back, holding the config lock throughout so
+// concurrent updates from other fti processes are not lost.
+func UpdateConfig(fn func(cfg *Config) error) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(configLockPath(path))
 	if err != nil {
 		return err
 	}
-	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+	defer unlock()
+
+	cfg, _, err := readConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(&cfg); err != nil {
+		return err
+	}
+	return writeConfig(path, cfg)
+}
+
+func configLockPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "config.lock")
+}
+
+// writeConfig replaces the file at path with cfg, stamped with the current
+// ConfigVersion. It writes a 0600 temporary file in the same directory and
+// renames it into place, so readers never see a partial file. The caller
+// holds the config lock.
+func writeConfig(path string, cfg Config) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("creating config dir: %w", err)
 	}
-	f, err := os.Create(path)
+	f, err := os.CreateTemp(dir, ".config-*.toml")
 	if err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
-	defer f.Close()
-	return toml.NewEncoder(f).Encode(cfg)
+	defer os.Remove(f.Name()) // no-op once renamed
+
+	cfg.Version = ConfigVersion
+	if err := f.Chmod(0600); err != nil && runtime.GOOS != "windows" {
+		f.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := toml.NewEncoder(f).Encode(cfg); err != nil {
+		f.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
 }
 
 // ResolveAPIKey returns the API key using precedence:
 // 1. flagValue (from --api-key flag)
 // 2. FTI_API_KEY env var
-// 3. ~/.fti/config.toml
+// 3. ~/.fti/config.toml, via the active profile and key backend (see ConfigAPIKey)
 func ResolveAPIKey(flagValue string) (string, error) {
 	if flagValue != "" {
 		return flagValue, nil
@@ -71,17 +277,31 @@ func ResolveAPIKey(flagValue string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return cfg.APIKey, nil
+	key, _, err := ConfigAPIKey(cfg)
+	return key, err
 }
 
-// ResolveBaseURL returns the API base URL, falling back to the default.
+// ResolveBaseURL returns the primary API base URL, falling back to the
+// default. See ResolveBaseURLs.
 func ResolveBaseURL(defaultURL string) string {
+	return ResolveBaseURLs(defaultURL)[0]
+}
+
+// ResolveBaseURLs returns the API base URLs in failover order: FTI_API_URL
+// (a comma-separated list), then api_urls, then api_url, then defaultURL.
+// The config settings come from the active profile if it sets them.
+func ResolveBaseURLs(defaultURL string) []string {
 	if v := os.Getenv("FTI_API_URL"); v != "" {
-		return v
+		if urls := NormalizeBaseURLs(strings.Split(v, ",")); len(urls) > 0 {
+			return urls
+		}
 	}
 	cfg, _ := LoadConfig()
+	if urls := NormalizeBaseURLs(cfg.APIURLs); len(urls) > 0 {
+		return urls
+	}
 	if cfg.APIURL != "" {
-		return cfg.APIURL
+		return []string{cfg.APIURL}
 	}
-	return defaultURL
+	return []string{defaultURL}
 }
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestSaveConfigAtomic(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FTI_HOME", dir)
+
+	if err := SaveConfig(Config{APIKey: "ti_live_x", Timeout: "5s"}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.toml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %04o, want 0600", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.Name() != "config.toml" && e.Name() != "config.lock" {
+			t.Errorf("left behind %s", e.Name())
+		}
+	}
+
+	cfg, err := ReadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIKey != "ti_live_x" || cfg.Timeout != "5s" || cfg.Version != ConfigVersion {
+		t.Errorf("read back %+v", cfg)
+	}
+}
+
+func TestUpdateConfigConcurrent(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- UpdateConfig(func(cfg *Config) error {
+				return SetConfigValue(cfg, fmt.Sprintf("profiles.p%d.api_url", i), "https://example.com")
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := ReadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != n {
+		t.Errorf("got %d profiles, want %d: updates were lost", len(cfg.Profiles), n)
+	}
+}
@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secretKeys are config keys whose values are masked unless revealed.
+var secretKeys = map[string]bool{"api_key": true}
+
+// IsSecretKey reports whether key (possibly "profiles.<name>.<key>") holds
+// a secret.
+func IsSecretKey(key string) bool {
+	return secretKeys[key[strings.LastIndexByte(key, '.')+1:]]
+}
+
+// configCheckers validate values beyond their type.
+var configCheckers = map[string]func(string) error{
+	"api_url":           checkURL,
+	"api_urls":          checkURL,
+	"retry_max_wait":    checkDuration,
+	"timeout":           checkDuration,
+	"endpoint_cooldown": checkDuration,
+	"max_response_size": func(v string) error { _, err := ParseSize(v); return err },
+	"proxy":             func(v string) error { _, err := ParseProxy(v); return err },
+	"trusted_projects": func(v string) error {
+		if !filepath.IsAbs(v) {
+			return fmt.Errorf("%q is not an absolute path", v)
+		}
+		return nil
+	},
+	"api_key_store": func(v string) error {
+		if v != StoreKeyring && v != StoreFile {
+			return fmt.Errorf("%q is not %q or %q", v, StoreKeyring, StoreFile)
+		}
+		return nil
+	},
+}
+
+func checkDuration(v string) error {
+	_, err := time.ParseDuration(v)
+	return err
+}
+
+func checkURL(v string) error {
+	u, err := url.Parse(v)
+	if err == nil && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+		err = fmt.Errorf("%q is not an http(s) URL", v)
+	}
+	return err
+}
+
+// ConfigKeys lists the keys of config.toml, excluding profile and
+// [defaults] sections and the file's version, which fti manages.
+func ConfigKeys() []string {
+	return tomlKeys(reflect.TypeOf(Config{}))
+}
+
+// ProfileKeys lists the keys a [profiles.<name>] section accepts.
+func ProfileKeys() []string {
+	return tomlKeys(reflect.TypeOf(Profile{}))
+}
+
+// nonSettingKeys are config.toml entries that are not single settings.
+var nonSettingKeys = map[string]bool{"version": true, "profiles": true, "defaults": true}
+
+func tomlKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := tomlName(t.Field(i)); name != "" && !nonSettingKeys[name] {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tomlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// fieldByTOML returns the field of struct v tagged name.
+func fieldByTOML(v reflect.Value, name string) reflect.Value {
+	for i := 0; i < v.NumField(); i++ {
+		if tomlName(v.Type().Field(i)) == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// ConfigValue returns the value of key in cfg as text, and whether it is
+// set. key is a top-level key or "profiles.<name>.<key>".
+func ConfigValue(cfg Config, key string) (string, bool, error) {
+	var out string
+	var set bool
+	err := withConfigField(&cfg, key, false, func(f reflect.Value) error {
+		out, set = formatField(f)
+		return nil
+	})
+	return out, set, err
+}
+
+// SetConfigValue parses value for key and stores it in cfg. Lists are
+// given comma-separated. Profiles are created as needed.
+func SetConfigValue(cfg *Config, key, value string) error {
+	return withConfigField(cfg, key, true, func(f reflect.Value) error {
+		return parseField(f, lastKey(key), value)
+	})
+}
+
+// UnsetConfigValue removes key from cfg, so its default applies again.
+func UnsetConfigValue(cfg *Config, key string) error {
+	return withConfigField(cfg, key, false, func(f reflect.Value) error {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	})
+}
+
+// withConfigField calls fn with the field for key. Profile fields are
+// edited on a copy that is stored back afterwards, since map elements are
+// not addressable. fn is not called for a profile that does not exist
+// unless create is set.
+func withConfigField(cfg *Config, key string, create bool, fn func(reflect.Value) error) error {
+	rest, isProfile := strings.CutPrefix(key, "profiles.")
+	if !isProfile {
+		if !containsKey(ConfigKeys(), key) {
+			return &UsageError{Err: fmt.Errorf("unknown config key %q (valid: %s, profiles.<name>.<key>)", key, strings.Join(ConfigKeys(), ", "))}
+		}
+		return fn(fieldByTOML(reflect.ValueOf(cfg).Elem(), key))
+	}
+
+	name, field, ok := strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return &UsageError{Err: fmt.Errorf("profile keys look like profiles.<name>.<key>, got %q", key)}
+	}
+	if !containsKey(ProfileKeys(), field) {
+		return &UsageError{Err: fmt.Errorf("unknown profile key %q (valid: %s)", field, strings.Join(ProfileKeys(), ", "))}
+	}
+	p, exists := cfg.Profiles[name]
+	if !exists && !create {
+		return nil
+	}
+	if err := fn(fieldByTOML(reflect.ValueOf(&p).Elem(), field)); err != nil {
+		return err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+	cfg.Profiles[name] = p
+	return nil
+}
+
+func containsKey(keys []string, key string) bool {
+	i := sort.SearchStrings(keys, key)
+	return i < len(keys) && keys[i] == key
+}
+
+func lastKey(key string) string {
+	return key[strings.LastIndexByte(key, '.')+1:]
+}
+
+// formatField renders a config field as text; lists are comma-separated.
+func formatField(f reflect.Value) (string, bool) {
+	switch f.Kind() {
+	case reflect.String:
+		return f.String(), f.String() != ""
+	case reflect.Bool:
+		return strconv.FormatBool(f.Bool()), f.Bool()
+	case reflect.Ptr:
+		if f.IsNil() {
+			return "", false
+		}
+		return fmt.Sprint(f.Elem().Interface()), true
+	case reflect.Slice:
+		items := make([]string, f.Len())
+		for i := range items {
+			items[i] = f.Index(i).String()
+		}
+		return strings.Join(items, ","), len(items) > 0
+	}
+	return fmt.Sprint(f.Interface()), true
+}
+
+// parseField sets f from text, checking it against the key's format.
+func parseField(f reflect.Value, key, value string) error {
+	check := configCheckers[key]
+	invalid := func(err error) error {
+		return &UsageError{Err: fmt.Errorf("invalid value for %s: %w", key, err)}
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		if check != nil {
+			if err := check(value); err != nil {
+				return invalid(err)
+			}
+		}
+		f.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid(fmt.Errorf("%q is not true or false", value))
+		}
+		f.SetBool(b)
+	case reflect.Ptr: // *int
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid(fmt.Errorf("%q is not a whole number", value))
+		}
+		f.Set(reflect.ValueOf(&n))
+	case reflect.Slice:
+		items := NormalizeBaseURLs(strings.Split(value, ","))
+		for _, it := range items {
+			if check != nil {
+				if err := check(it); err != nil {
+					return invalid(err)
+				}
+			}
+		}
+		f.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("config key %s cannot be set from the command line", key)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigVersion is the config.toml layout this build reads and writes.
+// SaveConfig stamps it into the file as "version".
+const ConfigVersion = 1
+
+// configMigrations[i] upgrades the raw contents of a version i file to
+// version i+1, e.g. by renaming or restructuring keys. Version 0 is a file
+// written before versions were recorded; its layout is version 1's.
+var configMigrations = []func(raw map[string]interface{}) error{
+	0: func(map[string]interface{}) error { return nil },
+}
+
+// decodeConfig parses config.toml contents into cfg, migrating older
+// layouts first. Files from a newer fti are refused rather than misread.
+func decodeConfig(data string, cfg *Config) (toml.MetaData, error) {
+	var raw map[string]interface{}
+	if _, err := toml.Decode(data, &raw); err != nil {
+		return toml.MetaData{}, err
+	}
+	version := 0
+	if v, set := raw["version"]; set {
+		n, ok := v.(int64)
+		if !ok {
+			return toml.MetaData{}, fmt.Errorf("config version must be a whole number, got %v", v)
+		}
+		version = int(n)
+	}
+	switch {
+	case version > ConfigVersion:
+		return toml.MetaData{}, fmt.Errorf("config has version %d but this fti only understands up to %d; upgrade fti", version, ConfigVersion)
+	case version == ConfigVersion:
+		return toml.Decode(data, cfg)
+	case version < 0:
+		return toml.MetaData{}, fmt.Errorf("config has invalid version %d", version)
+	}
+
+	for v := version; v < ConfigVersion; v++ {
+		if v >= len(configMigrations) || configMigrations[v] == nil {
+			return toml.MetaData{}, fmt.Errorf("no migration for config version %d", v)
+		}
+		if err := configMigrations[v](raw); err != nil {
+			return toml.MetaData{}, fmt.Errorf("migrating config from version %d: %w", v, err)
+		}
+	}
+	raw["version"] = int64(ConfigVersion)
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return toml.MetaData{}, err
+	}
+	return toml.Decode(buf.String(), cfg)
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeConfigVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"negative", "version = -1\ntimeout = \"5s\"\n", "invalid version -1"},
+		{"unversioned", "timeout = \"5s\"\n", ""},
+		{"zero", "version = 0\ntimeout = \"5s\"\n", ""},
+		{"current", fmt.Sprintf("version = %d\ntimeout = \"5s\"\n", ConfigVersion), ""},
+		{"newer", fmt.Sprintf("version = %d\ntimeout = \"5s\"\n", ConfigVersion+1), "upgrade fti"},
+		{"not a number", "version = \"one\"\n", "whole number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			_, err := decodeConfig(tt.data, &cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Timeout != "5s" || cfg.Version != ConfigVersion {
+				t.Errorf("got timeout %q version %d, want 5s and %d", cfg.Timeout, cfg.Version, ConfigVersion)
+			}
+		})
+	}
+}
+
+func TestDecodeConfigMissingMigration(t *testing.T) {
+	saved := configMigrations
+	defer func() { configMigrations = saved }()
+	configMigrations = nil
+
+	var cfg Config
+	if _, err := decodeConfig("version = 0\n", &cfg); err == nil || !strings.Contains(err.Error(), "no migration") {
+		t.Fatalf("err = %v, want missing migration", err)
+	}
+}
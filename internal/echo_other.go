@@ -0,0 +1,6 @@
+//go:build !unix && !windows
+
+package internal
+
+// Platforms without terminal control cannot prompt for secrets.
+func disableEcho() (func(), bool) { return nil, false }
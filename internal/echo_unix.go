@@ -0,0 +1,23 @@
+//go:build unix
+
+package internal
+
+import (
+	"os"
+	"os/exec"
+)
+
+// disableEcho turns off terminal echo on stdin and returns a function that
+// turns it back on. It reports false if stdin is not a terminal (or stty
+// is unavailable).
+func disableEcho() (func(), bool) {
+	stty := func(arg string) error {
+		c := exec.Command("stty", arg)
+		c.Stdin = os.Stdin
+		return c.Run()
+	}
+	if stty("-echo") != nil {
+		return nil, false
+	}
+	return func() { stty("echo") }, true //nolint:errcheck
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package internal
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// disableEcho turns off console echo on stdin and returns a function that
+// restores the previous mode. It reports false if stdin is not a console.
+func disableEcho() (func(), bool) {
+	h := windows.Handle(os.Stdin.Fd())
+	var mode uint32
+	if windows.GetConsoleMode(h, &mode) != nil {
+		return nil, false
+	}
+	if windows.SetConsoleMode(h, mode&^windows.ENABLE_ECHO_INPUT) != nil {
+		return nil, false
+	}
+	return func() { windows.SetConsoleMode(h, mode) }, true //nolint:errcheck
+}
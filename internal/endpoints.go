@@ -0,0 +1,247 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpointCooldown is how long an endpoint that failed is skipped
+	// before it is health-checked again.
+	DefaultEndpointCooldown = 30 * time.Second
+
+	// healthCheckTimeout bounds the /health probe of a recovering endpoint.
+	healthCheckTimeout = 3 * time.Second
+)
+
+// Endpoints is an ordered list of API base URLs to fail over between. An
+// endpoint that fails with a network error or 5xx is put in cooldown, and
+// must pass a GET /health check before it gets traffic again. Cooldowns are
+// kept in ~/.fti/endpoints.json so every fti process skips a dead endpoint.
+type Endpoints struct {
+	URLs     []string
+	Cooldown time.Duration
+
+	statePath string
+	lockPath  string
+
+	mu   sync.Mutex
+	down map[string]time.Time // URL → end of cooldown, when not persisted
+}
+
+// NewEndpoints returns a failover set for urls, in priority order.
+func NewEndpoints(urls []string) *Endpoints {
+	e := &Endpoints{URLs: urls, Cooldown: DefaultEndpointCooldown, down: map[string]time.Time{}}
+	if dir, err := stateDir(); err == nil {
+		e.statePath = filepath.Join(dir, "endpoints.json")
+		e.lockPath = filepath.Join(dir, "endpoints.lock")
+	}
+	return e
+}
+
+// Status reports when each endpoint's cooldown ends; healthy ones are absent.
+func (e *Endpoints) Status() map[string]time.Time {
+	out := map[string]time.Time{}
+	e.update(func(down map[string]time.Time) bool { //nolint:errcheck
+		for u, t := range down {
+			out[u] = t
+		}
+		return false
+	})
+	return out
+}
+
+// fail puts u in cooldown.
+func (e *Endpoints) fail(u string) {
+	e.update(func(down map[string]time.Time) bool { //nolint:errcheck
+		down[u] = time.Now().Add(e.Cooldown)
+		return true
+	})
+}
+
+// recover clears u's cooldown.
+func (e *Endpoints) recover(u string) {
+	e.update(func(down map[string]time.Time) bool { //nolint:errcheck
+		if _, ok := down[u]; !ok {
+			return false
+		}
+		delete(down, u)
+		return true
+	})
+}
+
+// update runs fn on the cooldown table, under the state file's lock when
+// it is persisted, saving it when fn reports a change.
+func (e *Endpoints) update(fn func(down map[string]time.Time) bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.statePath == "" {
+		fn(e.down)
+		return nil
+	}
+
+	unlock, err := lockFile(e.lockPath)
+	if err != nil {
+		fn(e.down)
+		return err
+	}
+	defer unlock()
+
+	down := map[string]time.Time{}
+	if data, err := os.ReadFile(e.statePath); err == nil {
+		json.Unmarshal(data, &down) //nolint:errcheck
+	}
+	if !fn(down) {
+		return nil
+	}
+	data, err := json.Marshal(down)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(e.statePath, data, 0600)
+}
+
+// candidates returns the endpoints to try, best first: those not in
+// cooldown in configured order, health-checking any whose cooldown has
+// ended, then the ones still cooling down (soonest back first) as a last
+// resort when nothing else is available.
+func (c *Client) candidates(ctx context.Context) []string {
+	e := c.Endpoints
+	down := e.Status()
+	now := time.Now()
+
+	var ready, cooling []string
+	for _, u := range e.URLs {
+		until, wasDown := down[u]
+		switch {
+		case !wasDown:
+			ready = append(ready, u)
+		case now.Before(until):
+			cooling = append(cooling, u)
+		case c.healthy(ctx, u):
+			c.Tracer.Notef("endpoint %s passed its health check", u)
+			e.recover(u)
+			ready = append(ready, u)
+		default:
+			c.Tracer.Notef("endpoint %s failed its health check, cooling down for %s", u, e.Cooldown)
+			e.fail(u)
+			cooling = append(cooling, u)
+		}
+	}
+	sort.SliceStable(cooling, func(i, j int) bool { return down[cooling[i]].Before(down[cooling[j]]) })
+	return append(ready, cooling...)
+}
+
+// healthy probes base's /health endpoint.
+func (c *Client) healthy(ctx context.Context, base string) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", base+"/health", nil)
+	if err != nil {
+		return false
+	}
+	c.setClientHeaders(req)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 400
+}
+
+// sendFailover sends req to the first usable endpoint, moving on to the
+// next when one fails with a network error or 5xx. Requests that are not
+// idempotent only move on when the connection could not be made, since
+// nothing was sent. Without Endpoints it is send.
+func (c *Client) sendFailover(req *http.Request) (*reply, error) {
+	if c.Endpoints == nil || len(c.Endpoints.URLs) < 2 {
+		return c.send(req)
+	}
+
+	var err error
+	for i, base := range c.candidates(req.Context()) {
+		r := req
+		if base != c.BaseURL {
+			if r, err = rebase(req, c.BaseURL, base); err != nil {
+				return nil, err
+			}
+		}
+		if i > 0 || base != c.BaseURL {
+			c.Tracer.Notef("using endpoint %s", base)
+		}
+
+		var rep *reply
+		rep, err = c.send(r)
+		if !endpointFailed(err) {
+			return rep, err
+		}
+		c.Endpoints.fail(base)
+		c.Tracer.Notef("endpoint %s failed (%v), cooling down for %s", base, err, c.Endpoints.Cooldown)
+		if req.Context().Err() != nil || !isIdempotent(req.Method) && !dialFailed(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// rebase returns a copy of req sent to base instead of from.
+func rebase(req *http.Request, from, base string) (*http.Request, error) {
+	u, err := url.Parse(base + strings.TrimPrefix(req.URL.String(), from))
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL, r.Host = u, ""
+	if req.GetBody != nil {
+		if r.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// endpointFailed reports whether err means the endpoint itself is unusable:
+// unreachable, or answering with a server error.
+func endpointFailed(err error) bool {
+	var netErr *NetworkError
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &netErr):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode >= 500
+	}
+	return false
+}
+
+// dialFailed reports whether err happened before a connection was made.
+func dialFailed(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) || errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// NormalizeBaseURLs trims trailing slashes and drops empty and duplicate
+// entries from urls.
+func NormalizeBaseURLs(urls []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, u := range urls {
+		u = strings.TrimRight(strings.TrimSpace(u), "/")
+		if u != "" && !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mirror is a test API mirror that can be switched between up and down.
+type mirror struct {
+	*httptest.Server
+	down atomic.Bool
+	hits atomic.Int32
+}
+
+func newMirror(t *testing.T, name string) *mirror {
+	m := &mirror{}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/health" {
+			m.hits.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"mirror":"` + name + `"}`)) //nolint:errcheck
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestEndpointFailoverAndRecovery(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	a, b := newMirror(t, "a"), newMirror(t, "b")
+	a.down.Store(true)
+
+	c := NewClient(a.URL, "")
+	c.Retries = 0
+	c.Endpoints = NewEndpoints([]string{a.URL, b.URL})
+	c.Endpoints.Cooldown = 200 * time.Millisecond
+
+	get := func() string {
+		t.Helper()
+		var out struct{ Mirror string }
+		if _, err := c.Get(context.Background(), "/x", nil, &out); err != nil {
+			t.Fatal(err)
+		}
+		return out.Mirror
+	}
+
+	if got := get(); got != "b" {
+		t.Fatalf("served by %q, want failover to b", got)
+	}
+	if _, cooling := c.Endpoints.Status()[a.URL]; !cooling {
+		t.Fatal("a is not in cooldown after failing")
+	}
+
+	// While a cools down it is not tried at all.
+	a.down.Store(false)
+	if got := get(); got != "b" || a.hits.Load() != 0 {
+		t.Fatalf("served by %q with %d hits on a during its cooldown", got, a.hits.Load())
+	}
+
+	// Once the cooldown ends, a passes its health check and is preferred again.
+	time.Sleep(250 * time.Millisecond)
+	if got := get(); got != "a" {
+		t.Fatalf("served by %q after the cooldown, want a", got)
+	}
+	if _, cooling := c.Endpoints.Status()[a.URL]; cooling {
+		t.Error("a is still in cooldown after recovering")
+	}
+}
+
+func TestEndpointCooldownShared(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	urls := []string{"https://a.example", "https://b.example"}
+
+	NewEndpoints(urls).fail(urls[0])
+	if _, cooling := NewEndpoints(urls).Status()[urls[0]]; !cooling {
+		t.Error("a cooldown is not seen by another Endpoints, as another fti process would")
+	}
+}
+
+func TestPostFailsOverOnlyWhenNotSent(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	a, b := newMirror(t, "a"), newMirror(t, "b")
+	a.down.Store(true)
+
+	c := NewClient(a.URL, "")
+	c.Retries = 0
+	c.Endpoints = NewEndpoints([]string{a.URL, b.URL})
+
+	// a answered, so the POST may have taken effect: no second attempt.
+	if _, err := c.Post(context.Background(), "/x", map[string]string{}, nil); err == nil {
+		t.Fatal("want a's 503 for a POST")
+	}
+	if b.hits.Load() != 0 {
+		t.Error("POST was repeated on b after a received it")
+	}
+
+	// A mirror that refuses connections never saw the request.
+	a.Close()
+	c.Endpoints = NewEndpoints([]string{a.URL, b.URL})
+	c.Endpoints.recover(a.URL)
+	var out struct{ Mirror string }
+	if _, err := c.Post(context.Background(), "/x", map[string]string{}, &out); err != nil || out.Mirror != "b" {
+		t.Fatalf("POST after a dial failure: %v, served by %q", err, out.Mirror)
+	}
+}
@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Exit codes returned by the fti binary. They are part of the CLI's public
+// contract; agents branch on them instead of parsing error text.
+const (
+	ExitOK          = 0
+	ExitError       = 1   // unclassified failure
+	ExitUsage       = 2   // invalid flags or arguments, or a 400/422 from the API
+	ExitAuth        = 3   // missing or rejected API key (401/403)
+	ExitNotFound    = 4   // unknown symbol or resource (404)
+	ExitRateLimited = 5   // quota exhausted (429) after retries
+	ExitNetwork     = 6   // connection failure or timeout
+	ExitServer      = 7   // API returned 5xx after retries
+	ExitInterrupted = 130 // cancelled by SIGINT/SIGTERM
+)
+
+// ErrNoAPIKey is returned by commands that need a key when none is configured.
+var ErrNoAPIKey = errors.New("API key required — run: fti auth login")
+
+// APIError is an HTTP error response from the Fan Token Intel API.
+type APIError struct {
+	StatusCode int    `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Method     string `json:"method"`
+	Endpoint   string `json:"endpoint"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	detail := e.Detail
+	if detail == "" {
+		detail = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("API error %d: %s%s", e.StatusCode, detail, requestIDSuffix(e.RequestID))
+}
+
+// newAPIError builds an APIError from a failed response, extracting FastAPI's
+// "detail", which is either a string or a list of validation errors.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		Endpoint:   resp.Request.URL.Path,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	var payload struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(body, &payload) != nil || len(payload.Detail) == 0 {
+		return e
+	}
+	var s string
+	if json.Unmarshal(payload.Detail, &s) == nil {
+		e.Detail = s
+		return e
+	}
+	var items []struct {
+		Loc []interface{} `json:"loc"`
+		Msg string        `json:"msg"`
+	}
+	if json.Unmarshal(payload.Detail, &items) == nil {
+		msgs := make([]string, 0, len(items))
+		for _, it := range items {
+			loc := make([]string, len(it.Loc))
+			for i, l := range it.Loc {
+				loc[i] = fmt.Sprint(l)
+			}
+			msgs = append(msgs, strings.Join(loc, ".")+": "+it.Msg)
+		}
+		e.Detail = strings.Join(msgs, "; ")
+	}
+	return e
+}
+
+// NetworkError is a failure to reach the API or read its response.
+type NetworkError struct {
+	Method    string
+	Endpoint  string
+	RequestID string
+	Err       error
+}
+
+func (e *NetworkError) Error() string {
+	return "request failed: " + e.Err.Error() + requestIDSuffix(e.RequestID)
+}
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// ResponseTooLargeError is returned when a response body exceeds the
+// client's MaxResponseSize. It is not retried.
+type ResponseTooLargeError struct {
+	Method    string
+	Endpoint  string
+	RequestID string
+	Limit     int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from %s %s exceeds %s (raise --max-response-size)%s",
+		e.Method, e.Endpoint, FormatSize(e.Limit), requestIDSuffix(e.RequestID))
+}
+
+// SchemaError reports a response that does not match the schema fti was
+// built for, e.g. after the API renamed a field.
+type SchemaError struct {
+	Method    string
+	Endpoint  string
+	RequestID string
+	Schema    string
+	Problems  []string
+}
+
+func (e *SchemaError) Error() string {
+	shown := e.Problems
+	more := ""
+	if len(shown) > 3 {
+		shown, more = shown[:3], fmt.Sprintf(" (and %d more)", len(e.Problems)-3)
+	}
+	return fmt.Sprintf("response from %s %s does not match the expected schema: %s%s%s",
+		e.Method, e.Endpoint, strings.Join(shown, "; "), more, requestIDSuffix(e.RequestID))
+}
+
+// UsageError marks invalid command-line input.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string { return e.Err.Error() }
+func (e *UsageError) Unwrap() error { return e.Err }
+
+// Classify maps err to its exit code and a short machine-readable kind.
+func Classify(err error) (code int, kind string) {
+	var apiErr *APIError
+	var netErr *NetworkError
+	var usageErr *UsageError
+	var sizeErr *ResponseTooLargeError
+	var schemaErr *SchemaError
+
+	switch {
+	case err == nil:
+		return ExitOK, ""
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted, "interrupted"
+	case errors.Is(err, ErrNoAPIKey):
+		return ExitAuth, "auth"
+	case errors.As(err, &usageErr):
+		return ExitUsage, "usage"
+	case errors.As(err, &sizeErr):
+		return ExitError, "too_large"
+	case errors.As(err, &schemaErr):
+		return ExitError, "schema"
+	case errors.As(err, &apiErr):
+		switch s := apiErr.StatusCode; {
+		case s == http.StatusUnauthorized || s == http.StatusForbidden:
+			return ExitAuth, "auth"
+		case s == http.StatusNotFound:
+			return ExitNotFound, "not_found"
+		case s == http.StatusTooManyRequests:
+			return ExitRateLimited, "rate_limited"
+		case s == http.StatusBadRequest || s == http.StatusUnprocessableEntity:
+			return ExitUsage, "validation"
+		case s >= 500:
+			return ExitServer, "server"
+		}
+		return ExitError, "api"
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return ExitNetwork, "network"
+	}
+	return ExitError, "error"
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service name API keys are filed under.
+const keyringService = "fti"
+
+// keyring stores keys in the OS keyring through its command-line tool:
+// security(1) on macOS and secret-tool(1) (libsecret) on Linux and BSD.
+type keyring struct {
+	tool string
+}
+
+func newKeyring() (*keyring, error) {
+	tool := "secret-tool"
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "windows", "plan9", "js", "wasip1":
+		return nil, fmt.Errorf("api_key_store %q is not supported on %s; use %q", StoreKeyring, runtime.GOOS, StoreFile)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("api_key_store %q needs %s, which is not installed", StoreKeyring, tool)
+	}
+	return &keyring{tool: tool}, nil
+}
+
+func (k *keyring) Get(account string) (string, error) {
+	var c *exec.Cmd
+	if k.tool == "security" {
+		c = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	} else {
+		c = exec.Command("secret-tool", "lookup", "service", keyringService, "account", account)
+	}
+	var out, stderr bytes.Buffer
+	c.Stdout, c.Stderr = &out, &stderr
+	err := c.Run()
+	key := strings.TrimSpace(out.String())
+	var exit *exec.ExitError
+	switch {
+	case errors.As(err, &exit) || err == nil && key == "":
+		// Both tools exit non-zero (secret-tool silently) for a missing item.
+		return "", ErrSecretNotFound
+	case err != nil:
+		return "", fmt.Errorf("reading keyring: %w", err)
+	}
+	return key, nil
+}
+
+func (k *keyring) Set(account, key string) error {
+	var c *exec.Cmd
+	if k.tool == "security" {
+		// -U updates an existing item. The key is visible in the process
+		// list briefly; security(1) offers no way to read it from stdin.
+		c = exec.Command("security", "add-generic-password", "-U", "-s", keyringService, "-a", account, "-w", key)
+	} else {
+		c = exec.Command("secret-tool", "store", "--label", "fti API key ("+account+")",
+			"service", keyringService, "account", account)
+		c.Stdin = strings.NewReader(key)
+	}
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("writing keyring: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
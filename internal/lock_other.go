@@ -0,0 +1,9 @@
+//go:build !unix && !windows
+
+package internal
+
+import "os"
+
+// Platforms without advisory locks run unsynchronised.
+func lockFD(f *os.File) error   { return nil }
+func unlockFD(f *os.File) error { return nil }
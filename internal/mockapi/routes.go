@@ -0,0 +1,661 @@
+package mockapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	mrand "math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// token is the fixture record behind the token list and detail routes.
+type token struct {
+	Symbol            string  `json:"symbol"`
+	Name              string  `json:"name"`
+	Team              string  `json:"team"`
+	League            string  `json:"league"`
+	Country           string  `json:"country"`
+	Price             float64 `json:"price"`
+	PriceChange1h     float64 `json:"price_change_1h"`
+	PriceChange24h    float64 `json:"price_change_24h"`
+	PriceChange7d     float64 `json:"price_change_7d"`
+	Volume24h         float64 `json:"volume_24h"`
+	MarketCap         float64 `json:"market_cap"`
+	TotalHolders      int     `json:"total_holders"`
+	HolderChange24h   int     `json:"holder_change_24h"`
+	HealthScore       float64 `json:"health_score"`
+	HealthGrade       string  `json:"health_grade"`
+	Liquidity1pct     float64 `json:"liquidity_1pct"`
+	SpreadBps         float64 `json:"spread_bps"`
+	TotalSupply       int64   `json:"total_supply"`
+	CirculatingSupply int64   `json:"circulating_supply"`
+	LaunchDate        string  `json:"launch_date"`
+}
+
+var exchanges = []string{"Binance", "Chiliz Exchange", "OKX", "Bitget", "Paribu"}
+
+// seeded returns a generator whose output depends only on parts and the
+// current time bucket, so repeated calls within a bucket agree.
+func seeded(bucket time.Duration, parts ...string) *mrand.Rand {
+	h := fnv.New64a()
+	for _, p := range parts {
+		h.Write([]byte(p)) //nolint:errcheck
+	}
+	if bucket > 0 {
+		fmt.Fprint(h, time.Now().Truncate(bucket).Unix())
+	}
+	return mrand.New(mrand.NewSource(int64(h.Sum64())))
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+func isoTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// ── /api/tokens ─────────────────────────────────────────────────────────────
+
+func (s *Server) tokens(w http.ResponseWriter, r *http.Request) {
+	if s.serveFixture(w, "tokens") {
+		return
+	}
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sortBy := r.URL.Query().Get("sort_by")
+	desc := r.URL.Query().Get("order") != "asc"
+	key := func(t token) float64 {
+		switch sortBy {
+		case "price_change_24h":
+			return t.PriceChange24h
+		case "market_cap":
+			return t.MarketCap
+		case "health_score":
+			return t.HealthScore
+		default:
+			return t.Volume24h
+		}
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if desc {
+			return key(tokens[i]) > key(tokens[j])
+		}
+		return key(tokens[i]) < key(tokens[j])
+	})
+
+	out := make([]map[string]interface{}, len(tokens))
+	for i, t := range tokens {
+		out[i] = map[string]interface{}{
+			"symbol":           t.Symbol,
+			"name":             t.Name,
+			"team":             t.Team,
+			"price":            t.Price,
+			"price_change_1h":  t.PriceChange1h,
+			"price_change_24h": t.PriceChange24h,
+			"volume_24h":       t.Volume24h,
+			"market_cap":       t.MarketCap,
+			"health_grade":     t.HealthGrade,
+			"health_score":     t.HealthScore,
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(strings.TrimPrefix(r.URL.Path, "/api/tokens/"))
+	if s.serveFixture(w, "token_"+symbol) {
+		return
+	}
+	t, ok := s.lookupToken(w, symbol)
+	if !ok {
+		return
+	}
+
+	rng := seeded(time.Minute, "exchanges", t.Symbol)
+	var venues []map[string]interface{}
+	for i, name := range exchanges[:3+rng.Intn(len(exchanges)-2)] {
+		price := t.Price * (1 + (rng.Float64()-0.5)*0.004)
+		spread := t.SpreadBps * (0.8 + rng.Float64()*0.6)
+		half := price * spread / 20000
+		venues = append(venues, map[string]interface{}{
+			"name":       name,
+			"price":      round(price, 6),
+			"volume_24h": round(t.Volume24h*[]float64{0.55, 0.2, 0.12, 0.08, 0.05}[i], 0),
+			"spread_bps": round(spread, 1),
+			"best_bid":   round(price-half, 6),
+			"best_ask":   round(price+half, 6),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token": map[string]interface{}{
+			"id":                 int(fnv32(t.Symbol) % 1000),
+			"symbol":             t.Symbol,
+			"name":               t.Name,
+			"team":               t.Team,
+			"league":             t.League,
+			"country":            t.Country,
+			"total_supply":       t.TotalSupply,
+			"circulating_supply": t.CirculatingSupply,
+			"launch_date":        t.LaunchDate,
+		},
+		"metrics": map[string]interface{}{
+			"price":             t.Price,
+			"price_change_1h":   t.PriceChange1h,
+			"price_change_24h":  t.PriceChange24h,
+			"price_change_7d":   t.PriceChange7d,
+			"volume_24h":        t.Volume24h,
+			"market_cap":        t.MarketCap,
+			"total_holders":     t.TotalHolders,
+			"holder_change_24h": t.HolderChange24h,
+			"health_score":      t.HealthScore,
+			"health_grade":      t.HealthGrade,
+			"liquidity_1pct":    t.Liquidity1pct,
+			"spread_bps":        t.SpreadBps,
+		},
+		"exchanges": venues,
+	})
+}
+
+func fnv32(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s)) //nolint:errcheck
+	return h.Sum32()
+}
+
+// ── /api/history/price/{symbol} ─────────────────────────────────────────────
+
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(strings.TrimPrefix(r.URL.Path, "/api/history/price/"))
+	if s.serveFixture(w, "history_"+symbol) {
+		return
+	}
+	t, ok := s.lookupToken(w, symbol)
+	if !ok {
+		return
+	}
+
+	days := intParam(r, "days", 7)
+	if days < 1 || days > 365 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"detail": []map[string]interface{}{
+				{"loc": []string{"query", "days"}, "msg": "ensure this value is between 1 and 365", "type": "value_error"},
+			},
+		})
+		return
+	}
+	step := map[string]time.Duration{"1h": time.Hour, "4h": 4 * time.Hour, "1d": 24 * time.Hour}[r.URL.Query().Get("interval")]
+	if step == 0 {
+		step = time.Hour
+	}
+
+	// Walk backwards from the current price so the series ends where the
+	// token detail says it is.
+	rng := seeded(time.Hour, "history", t.Symbol)
+	end := time.Now().UTC().Truncate(step)
+	n := int(time.Duration(days) * 24 * time.Hour / step)
+	points := make([]map[string]interface{}, n)
+	price := t.Price
+	for i := n - 1; i >= 0; i-- {
+		points[i] = map[string]interface{}{
+			"time":      isoTime(end.Add(-time.Duration(n-1-i) * step)),
+			"price":     round(price, 6),
+			"volume":    round(t.Volume24h*float64(step)/float64(24*time.Hour)*(0.5+rng.Float64()), 0),
+			"spread":    round(t.SpreadBps*(0.7+rng.Float64()*0.6), 1),
+			"liquidity": round(t.Liquidity1pct*(0.8+rng.Float64()*0.4), 0),
+		}
+		price /= 1 + rng.NormFloat64()*0.006*math.Sqrt(step.Hours())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"symbol":       t.Symbol,
+		"period_hours": days * 24,
+		"data_points":  n,
+		"prices":       points,
+	})
+}
+
+// ── /api/whales/combined ────────────────────────────────────────────────────
+
+type whaleTrade struct {
+	Time         string  `json:"time"`
+	Venue        string  `json:"venue"`
+	Symbol       string  `json:"symbol"`
+	Exchange     string  `json:"exchange"`
+	Side         string  `json:"side"`
+	Price        float64 `json:"price"`
+	Quantity     float64 `json:"quantity"`
+	ValueUSD     float64 `json:"value_usd"`
+	IsAggressive bool    `json:"is_aggressive"`
+	TxHash       string  `json:"tx_hash,omitempty"`
+}
+
+// whaleTrades generates the trade tape for the last hours, newest first.
+// The tape is stable within a minute and grows as time passes.
+func whaleTrades(tokens []token, hours int) []whaleTrade {
+	now := time.Now().UTC().Truncate(time.Minute)
+	var trades []whaleTrade
+	for _, t := range tokens {
+		rng := seeded(time.Minute, "whales", t.Symbol)
+		perHour := 0.5 + t.Volume24h/1_000_000
+		count := int(perHour * float64(hours))
+		for i := 0; i < count; i++ {
+			at := now.Add(-time.Duration(rng.Int63n(int64(time.Duration(hours) * time.Hour))))
+			trades = append(trades, newWhaleTrade(rng, t, at))
+		}
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].Time > trades[j].Time })
+	return trades
+}
+
+// newWhaleTrade builds a trade in t at time at; rng drives the numbers.
+func newWhaleTrade(rng *mrand.Rand, t token, at time.Time) whaleTrade {
+	value := 50_000 * math.Exp(rng.ExpFloat64()*0.9)
+	price := t.Price * (1 + rng.NormFloat64()*0.01)
+	tr := whaleTrade{
+		Time:         isoTime(at),
+		Venue:        "cex",
+		Symbol:       t.Symbol,
+		Exchange:     exchanges[rng.Intn(3)],
+		Side:         []string{"buy", "sell"}[rng.Intn(2)],
+		Price:        round(price, 6),
+		Quantity:     round(value/price, 0),
+		ValueUSD:     round(value, 2),
+		IsAggressive: rng.Float64() < 0.3,
+	}
+	if rng.Float64() < 0.25 {
+		tr.Venue = "dex"
+		tr.Exchange = "KayenSwap"
+		tr.TxHash = fmt.Sprintf("0x%016x%016x", rng.Uint64(), rng.Uint64())
+	}
+	return tr
+}
+
+func (s *Server) whales(w http.ResponseWriter, r *http.Request) {
+	if s.serveFixture(w, "whales") {
+		return
+	}
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	symbol := strings.ToUpper(q.Get("symbol"))
+	if symbol != "" {
+		t, ok := s.lookupToken(w, symbol)
+		if !ok {
+			return
+		}
+		tokens = []token{t}
+	}
+	minValue := floatParam(r, "min_value", 50_000)
+	limit := intParam(r, "limit", 50)
+
+	matched := []whaleTrade{}
+	for _, tr := range whaleTrades(tokens, intParam(r, "hours", 24)) {
+		if tr.ValueUSD >= minValue {
+			matched = append(matched, tr)
+		}
+	}
+
+	// Cursor pagination: the cursor is an opaque encoding of the offset.
+	offset := 0
+	if c := q.Get("cursor"); c != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(c)
+		if err == nil {
+			offset, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || offset < 0 {
+			writeDetail(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+	}
+	page, next := paginate(matched, offset, limit)
+	nextCursor := ""
+	if next > 0 {
+		nextCursor = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(next)))
+	}
+
+	cex, dex := 0, 0
+	for _, tr := range page {
+		if tr.Venue == "dex" {
+			dex++
+		} else {
+			cex++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"transactions":  page,
+		"count":         len(page),
+		"cex_count":     cex,
+		"dex_count":     dex,
+		"threshold_usd": minValue,
+		"next_cursor":   nextCursor,
+		// Whole minutes, so polling within a minute can be answered with 304.
+		"timestamp": isoTime(time.Now().Truncate(time.Minute)),
+	})
+}
+
+// ── /api/v1/signals/* ───────────────────────────────────────────────────────
+
+type signal struct {
+	ID                 string  `json:"id"`
+	Token              string  `json:"token"`
+	Direction          string  `json:"direction"`
+	Tier               string  `json:"tier"`
+	SellRatio          float64 `json:"sell_ratio"`
+	ConfidenceScore    float64 `json:"confidence_score"`
+	EntryPrice         float64 `json:"entry_price"`
+	TargetPrice        float64 `json:"target_price"`
+	StopPrice          float64 `json:"stop_price"`
+	CreatedAt          string  `json:"created_at"`
+	ExpiresAt          string  `json:"expires_at,omitempty"`
+	PrimaryReason      string  `json:"primary_reason,omitempty"`
+	MaxProfitPct       float64 `json:"max_profit_pct"`
+	TrailingStopStatus string  `json:"trailing_stop_status,omitempty"`
+	OutcomeStatus      string  `json:"outcome_status,omitempty"`
+	PnlPct             float64 `json:"pnl_pct,omitempty"`
+	ExitTime           string  `json:"exit_time,omitempty"`
+	ExitPrice          float64 `json:"exit_price,omitempty"`
+}
+
+var reasons = []string{
+	"Sustained whale selling on CEX order books",
+	"Sell ratio spike ahead of match day",
+	"Liquidity thinning with widening spreads",
+	"Post-match sell-the-news pattern",
+}
+
+// newSignal builds a signal for t created at created; rng drives the numbers.
+func newSignal(rng *mrand.Rand, t token, created time.Time) signal {
+	conf := 0.6 + rng.Float64()*0.35
+	tier := "low"
+	switch {
+	case conf >= 0.85:
+		tier = "high"
+	case conf >= 0.72:
+		tier = "medium"
+	}
+	entry := t.Price * (1 + rng.NormFloat64()*0.02)
+	return signal{
+		ID:              fmt.Sprintf("sig_%s_%d", strings.ToLower(t.Symbol), created.Unix()),
+		Token:           t.Symbol,
+		Direction:       "short",
+		Tier:            tier,
+		SellRatio:       round(0.55+rng.Float64()*0.35, 2),
+		ConfidenceScore: round(conf, 2),
+		EntryPrice:      round(entry, 6),
+		TargetPrice:     round(entry*0.95, 6),
+		StopPrice:       round(entry*1.03, 6),
+		CreatedAt:       isoTime(created),
+		PrimaryReason:   reasons[rng.Intn(len(reasons))],
+	}
+}
+
+// activeSignal returns t's open signal, if it has one. The set of open
+// signals, and their IDs, are stable within an hour.
+func activeSignal(t token, now time.Time) (signal, bool) {
+	rng := seeded(time.Hour, "active", t.Symbol)
+	if rng.Float64() > 0.45 {
+		return signal{}, false
+	}
+	sig := newSignal(rng, t, now.Truncate(time.Hour).Add(-time.Duration(rng.Intn(240))*time.Minute))
+	created, _ := time.Parse(time.RFC3339, sig.CreatedAt)
+	sig.ExpiresAt = isoTime(created.Add(24 * time.Hour))
+	sig.MaxProfitPct = round(rng.Float64()*3.5, 2)
+	sig.TrailingStopStatus = []string{"inactive", "armed", "trailing"}[rng.Intn(3)]
+	return sig, true
+}
+
+func (s *Server) signalsActive(w http.ResponseWriter, r *http.Request) {
+	if s.serveFixture(w, "signals_active") {
+		return
+	}
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := strings.ToUpper(r.URL.Query().Get("token"))
+	minConf := floatParam(r, "min_confidence", 0)
+	now := time.Now().UTC()
+
+	active := []signal{}
+	for _, t := range tokens {
+		if filter != "" && t.Symbol != filter {
+			continue
+		}
+		sig, ok := activeSignal(t, now)
+		if ok && sig.ConfidenceScore >= minConf {
+			active = append(active, sig)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"active_signals": len(active),
+		"signals":        active,
+	})
+}
+
+func (s *Server) signalsHistory(w http.ResponseWriter, r *http.Request) {
+	if s.serveFixture(w, "signals_history") {
+		return
+	}
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	filter := strings.ToUpper(q.Get("token"))
+	outcome := q.Get("outcome")
+	days := intParam(r, "days", 30)
+	limit := intParam(r, "limit", 50)
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	history := []signal{}
+	for _, t := range tokens {
+		if filter != "" && t.Symbol != filter {
+			continue
+		}
+		rng := seeded(0, "history", t.Symbol)
+		// Roughly one signal every four days per token, going back a year.
+		for age := time.Duration(rng.Intn(96)) * time.Hour; age < time.Duration(days)*24*time.Hour; age += time.Duration(48+rng.Intn(96)) * time.Hour {
+			created := now.Add(-age)
+			sig := newSignal(rng, t, created)
+			exit := created.Add(time.Duration(2+rng.Intn(22)) * time.Hour)
+			switch x := rng.Float64(); {
+			case x < 0.55:
+				sig.OutcomeStatus = "target_hit"
+				sig.ExitPrice = sig.TargetPrice
+			case x < 0.85:
+				sig.OutcomeStatus = "stopped_out"
+				sig.ExitPrice = sig.StopPrice
+			default:
+				sig.OutcomeStatus = "expired"
+				sig.ExitPrice = round(sig.EntryPrice*(1+rng.NormFloat64()*0.01), 6)
+			}
+			sig.PnlPct = round((sig.EntryPrice-sig.ExitPrice)/sig.EntryPrice*100, 2)
+			sig.MaxProfitPct = round(math.Max(sig.PnlPct, 0)+rng.Float64()*1.5, 2)
+			sig.ExitTime = isoTime(exit)
+			if outcome == "" || sig.OutcomeStatus == outcome {
+				history = append(history, sig)
+			}
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].CreatedAt > history[j].CreatedAt })
+
+	// Offset pagination.
+	offset := max(intParam(r, "offset", 0), 0)
+	page, next := paginate(history, offset, limit)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"signals":  page,
+		"total":    len(history),
+		"offset":   offset,
+		"has_more": next > 0,
+	})
+}
+
+// paginate returns items[offset:offset+limit] and the offset of the next
+// page, or 0 if this is the last one.
+func paginate[T any](items []T, offset, limit int) ([]T, int) {
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	next := 0
+	if end < len(items) {
+		next = end
+	}
+	return items[offset:end], next
+}
+
+// ── /api/matches/upcoming ───────────────────────────────────────────────────
+
+func (s *Server) matches(w http.ResponseWriter, r *http.Request) {
+	if s.serveFixture(w, "matches") {
+		return
+	}
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := strings.ToUpper(r.URL.Query().Get("token"))
+	days := intParam(r, "days", 14)
+	limit := intParam(r, "limit", 100)
+	rng := seeded(24*time.Hour, "matches")
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// Pair clubs from the same league; everyone else plays an opponent
+	// without a fan token.
+	var out []map[string]interface{}
+	for day := 1; day <= days; day++ {
+		for _, t := range tokens {
+			if rng.Float64() > 0.18 {
+				continue
+			}
+			home, away := t.Team, "Opponent FC"
+			homeTok, awayTok := t.Symbol, ""
+			for _, o := range tokens {
+				if o.Symbol != t.Symbol && o.League == t.League && rng.Float64() < 0.5 {
+					away, awayTok = o.Team, o.Symbol
+					break
+				}
+			}
+			if rng.Intn(2) == 0 {
+				home, away, homeTok, awayTok = away, home, awayTok, homeTok
+			}
+			if filter != "" && homeTok != filter && awayTok != filter {
+				continue
+			}
+			kickoff := start.Add(time.Duration(day)*24*time.Hour + time.Duration(17+rng.Intn(5))*time.Hour)
+			out = append(out, map[string]interface{}{
+				"match_id":         fmt.Sprintf("m_%d_%s", kickoff.Unix(), strings.ToLower(t.Symbol)),
+				"home_team":        home,
+				"away_team":        away,
+				"match_date":       isoTime(kickoff),
+				"competition":      t.League,
+				"status":           "scheduled",
+				"importance_score": round(30+rng.Float64()*70, 0),
+				"home_token":       homeTok,
+				"away_token":       awayTok,
+				"days_until":       day,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i]["match_date"].(string) < out[j]["match_date"].(string)
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	if out == nil {
+		out = []map[string]interface{}{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":        len(out),
+		"days":         days,
+		"token_filter": filter,
+		"matches":      out,
+	})
+}
+
+// ── /api/v1/auth/* ──────────────────────────────────────────────────────────
+
+func (s *Server) authMe(w http.ResponseWriter, r *http.Request) {
+	data, _ := s.fixture("auth_me", true)
+	var me map[string]interface{}
+	if err := json.Unmarshal(data, &me); err != nil {
+		writeDetail(w, http.StatusInternalServerError, "auth_me fixture: "+err.Error())
+		return
+	}
+	if s.RateLimit > 0 {
+		me["rate_limit_per_minute"] = s.RateLimit
+	}
+	writeJSON(w, http.StatusOK, me)
+}
+
+func (s *Server) authRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeDetail(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	if s.serveFixture(w, "auth_register") {
+		return
+	}
+	var req struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Scope string `json:"scope"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" || req.Email == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"detail": []map[string]interface{}{
+				{"loc": []string{"body", "email"}, "msg": "name and email are required", "type": "value_error.missing"},
+			},
+		})
+		return
+	}
+	caps := []string{"read"}
+	if req.Scope == "full" {
+		caps = append(caps, "signals", "write")
+	}
+	limit := 60
+	if s.RateLimit > 0 {
+		limit = s.RateLimit
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"agent_id":              "agt_mock_" + randomHex(3),
+		"api_key":               "ti_live_mock_" + randomHex(12),
+		"name":                  req.Name,
+		"tier":                  "free",
+		"rate_limit_per_minute": limit,
+		"email_verified":        false,
+		"capabilities":          caps,
+		"message":               "Mock key — only valid against fti dev mock-server.",
+	})
+}
@@ -0,0 +1,344 @@
+// Package mockapi is a local stand-in for the Fan Token Intel API. It serves
+// realistic fixture data for every endpoint the CLI uses, with optional
+// latency, error injection and rate limiting, so fti can be developed and
+// exercised with FTI_API_URL pointing at it.
+package mockapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	mrand "math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var embedded embed.FS
+
+// Server serves the mock API. The zero value serves the embedded fixtures
+// with no latency or injected errors.
+type Server struct {
+	// FixtureDir, if set, is searched before the embedded fixtures. A file
+	// named after a route replaces that route's response verbatim: tokens,
+	// token_<SYMBOL>, history_<SYMBOL>, whales, signals_active,
+	// signals_history, matches, auth_me and auth_register (each with a .json
+	// extension). tokens.json also becomes the base data for generated routes.
+	FixtureDir string
+
+	// Latency is added to every response, plus up to Jitter more.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// ErrorRate is the fraction of requests (0-1) answered with one of
+	// ErrorCodes instead of data.
+	ErrorRate  float64
+	ErrorCodes []int
+
+	// RateLimit, if positive, is enforced per minute with 429 + Retry-After
+	// and advertised in X-RateLimit-* headers.
+	RateLimit int
+
+	// StreamInterval spaces the events of the /api/v1/stream/* endpoints
+	// (default 2s). StreamDropAfter, if positive, closes each stream after
+	// that many events to exercise reconnects. NoStream answers the stream
+	// endpoints with 404, as an API without push support would.
+	StreamInterval  time.Duration
+	StreamDropAfter int
+	NoStream        bool
+
+	// Log receives one line per request. Nil disables logging.
+	Log io.Writer
+
+	started     time.Time
+	mu          sync.Mutex
+	rng         *mrand.Rand
+	windowStart time.Time
+	windowCount int
+}
+
+// Handler returns the HTTP handler for the mock API.
+func (s *Server) Handler() http.Handler {
+	s.rng = mrand.New(mrand.NewSource(time.Now().UnixNano()))
+	s.started = time.Now()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("/api/tokens", s.tokens)
+	mux.HandleFunc("/api/tokens/", s.token)
+	mux.HandleFunc("/api/history/price/", s.history)
+	mux.HandleFunc("/api/whales/combined", s.whales)
+	mux.HandleFunc("/api/matches/upcoming", s.matches)
+	mux.HandleFunc("/api/v1/signals/active", s.requireKey(s.signalsActive))
+	mux.HandleFunc("/api/v1/signals/history", s.requireKey(s.signalsHistory))
+	mux.HandleFunc("/api/v1/auth/me", s.requireKey(s.authMe))
+	mux.HandleFunc("/api/v1/auth/register", s.authRegister)
+	mux.HandleFunc("/api/v1/stream/whales", s.streamWhales)
+	mux.HandleFunc("/api/v1/stream/signals", s.requireKey(s.streamSignals))
+
+	return s.middleware(etags(mux))
+}
+
+// gzipMinSize is the smallest body the mock compresses.
+const gzipMinSize = 512
+
+// etags tags successful GET responses with a hash of their body and answers
+// a matching If-None-Match with 304 Not Modified. Bodies of gzipMinSize or
+// more are gzipped for clients that accept it. Event streams pass through.
+func etags(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/api/v1/stream/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		buf := &bufferedWriter{header: http.Header{}, status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+
+		for k, v := range buf.header {
+			w.Header()[k] = v
+		}
+		if buf.status == http.StatusOK {
+			sum := sha256.Sum256(buf.body.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.Header().Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		body := buf.body.Bytes()
+		w.Header().Add("Vary", "Accept-Encoding")
+		if len(body) >= gzipMinSize && strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			var gz bytes.Buffer
+			zw := gzip.NewWriter(&gz)
+			zw.Write(body) //nolint:errcheck
+			zw.Close()
+			body = gz.Bytes()
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(buf.status)
+		w.Write(body) //nolint:errcheck
+	})
+}
+
+// middleware applies request IDs, latency, rate limiting, error injection
+// and logging around every route.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		reqID := r.Header.Get("X-Request-ID")
+		if reqID == "" {
+			reqID = "mock-" + randomHex(6)
+		}
+		w.Header().Set("X-Request-ID", reqID)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if s.Log != nil {
+				fmt.Fprintf(s.Log, "%s %s %d %s\n", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+			}
+		}()
+
+		if d := s.delay(); d > 0 {
+			select {
+			case <-time.After(d):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if !s.allow(rec) {
+			return
+		}
+		if code, ok := s.injectedError(); ok {
+			writeDetail(rec, code, "injected error: "+http.StatusText(code))
+			return
+		}
+		next.ServeHTTP(rec, r)
+	})
+}
+
+func (s *Server) delay() time.Duration {
+	d := s.Latency
+	if s.Jitter > 0 {
+		s.mu.Lock()
+		d += time.Duration(s.rng.Int63n(int64(s.Jitter)))
+		s.mu.Unlock()
+	}
+	return d
+}
+
+// allow enforces RateLimit over fixed one-minute windows.
+func (s *Server) allow(w http.ResponseWriter) bool {
+	if s.RateLimit <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	now := time.Now()
+	if now.Sub(s.windowStart) >= time.Minute {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	remaining := s.RateLimit - s.windowCount
+	reset := s.windowStart.Add(time.Minute)
+	s.mu.Unlock()
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.RateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(remaining, 0)))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	if remaining < 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(reset).Seconds())+1))
+		writeDetail(w, http.StatusTooManyRequests, "Rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+func (s *Server) injectedError() (int, bool) {
+	if s.ErrorRate <= 0 || len(s.ErrorCodes) == 0 {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rng.Float64() >= s.ErrorRate {
+		return 0, false
+	}
+	return s.ErrorCodes[s.rng.Intn(len(s.ErrorCodes))], true
+}
+
+func (s *Server) requireKey(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if key == "" || key == r.Header.Get("Authorization") {
+			writeDetail(w, http.StatusUnauthorized, "Missing or invalid API key")
+			return
+		}
+		h(w, r)
+	}
+}
+
+// fixture returns the raw override for name from FixtureDir, or from the
+// embedded set when embeddedOK is true.
+func (s *Server) fixture(name string, embeddedOK bool) ([]byte, bool) {
+	if s.FixtureDir != "" {
+		if data, err := os.ReadFile(filepath.Join(s.FixtureDir, name+".json")); err == nil {
+			return data, true
+		}
+	}
+	if embeddedOK {
+		if data, err := fs.ReadFile(embedded, "fixtures/"+name+".json"); err == nil {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// serveFixture writes the FixtureDir override for name, if any.
+func (s *Server) serveFixture(w http.ResponseWriter, name string) bool {
+	data, ok := s.fixture(name, false)
+	if !ok {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data) //nolint:errcheck
+	return true
+}
+
+func (s *Server) baseTokens() ([]token, error) {
+	data, _ := s.fixture("tokens", true)
+	var tokens []token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("tokens fixture: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *Server) lookupToken(w http.ResponseWriter, symbol string) (token, bool) {
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return token{}, false
+	}
+	for _, t := range tokens {
+		if strings.EqualFold(t.Symbol, symbol) {
+			return t, true
+		}
+	}
+	writeDetail(w, http.StatusNotFound, fmt.Sprintf("Token %s not found", strings.ToUpper(symbol)))
+	return token{}, false
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// bufferedWriter holds a response until etags has seen all of it.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedWriter) Header() http.Header         { return b.header }
+func (b *bufferedWriter) WriteHeader(code int)        { b.status = code }
+func (b *bufferedWriter) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+// writeDetail writes a FastAPI-style error body.
+func writeDetail(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b) //nolint:errcheck
+	return hex.EncodeToString(b)
+}
+
+func intParam(r *http.Request, name string, def int) int {
+	if v, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil {
+		return v
+	}
+	return def
+}
+
+func floatParam(r *http.Request, name string, def float64) float64 {
+	if v, err := strconv.ParseFloat(r.URL.Query().Get(name), 64); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,148 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultStreamInterval spaces stream events when StreamInterval is unset.
+	defaultStreamInterval = 2 * time.Second
+	// streamBacklog is how many missed events a resuming client is sent.
+	streamBacklog = 100
+	// streamKeepAlive is how often an idle stream sends a comment line.
+	streamKeepAlive = 15 * time.Second
+)
+
+// streamEvent generates event n (1-based) of a stream, occurring at at. It
+// returns false when the event is filtered out for this request.
+type streamEvent func(n int64, at time.Time) (typ string, data interface{}, ok bool)
+
+// serveStream writes a Server-Sent Events stream. Event n happens n intervals
+// after the server started and is generated from n alone, so a client that
+// reconnects with Last-Event-ID receives exactly the events it missed (up to
+// streamBacklog of them).
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, gen streamEvent) {
+	if s.NoStream {
+		writeDetail(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeDetail(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	interval := s.StreamInterval
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	}
+	current := func() int64 { return int64(time.Since(s.started) / interval) }
+
+	next := current() + 1
+	if last, err := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64); err == nil {
+		next = max(last+1, current()-streamBacklog+1, 1)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", time.Second.Milliseconds())
+	flusher.Flush()
+
+	sent := 0
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		for ; next <= current(); next++ {
+			typ, data, ok := gen(next, s.started.Add(time.Duration(next)*interval))
+			if !ok {
+				continue
+			}
+			payload, _ := json.Marshal(data)
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", next, typ, payload)
+			flusher.Flush()
+			sent++
+			if s.StreamDropAfter > 0 && sent >= s.StreamDropAfter {
+				return // simulate a dropped connection
+			}
+		}
+		wait := time.Until(s.started.Add(time.Duration(next) * interval))
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// ── /api/v1/stream/* ────────────────────────────────────────────────────────
+
+func (s *Server) streamWhales(w http.ResponseWriter, r *http.Request) {
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	q := r.URL.Query()
+	symbol := strings.ToUpper(q.Get("symbol"))
+	if symbol != "" {
+		t, ok := s.lookupToken(w, symbol)
+		if !ok {
+			return
+		}
+		tokens = []token{t}
+	}
+	minValue := floatParam(r, "min_value", 50_000)
+
+	s.serveStream(w, r, func(n int64, at time.Time) (string, interface{}, bool) {
+		rng := seeded(0, "stream", "whale", strconv.FormatInt(n, 10))
+		tr := newWhaleTrade(rng, tokens[rng.Intn(len(tokens))], at)
+		return "whale", tr, tr.ValueUSD >= minValue
+	})
+}
+
+// streamSignals opens signals on tokens without one and updates or closes
+// the signals that signals/active currently reports.
+func (s *Server) streamSignals(w http.ResponseWriter, r *http.Request) {
+	tokens, err := s.baseTokens()
+	if err != nil {
+		writeDetail(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	filter := strings.ToUpper(r.URL.Query().Get("token"))
+	minConf := floatParam(r, "min_confidence", 0)
+
+	s.serveStream(w, r, func(n int64, at time.Time) (string, interface{}, bool) {
+		rng := seeded(0, "stream", "signal", strconv.FormatInt(n, 10))
+		t := tokens[rng.Intn(len(tokens))]
+		if filter != "" && t.Symbol != filter {
+			return "", nil, false
+		}
+
+		sig, open := activeSignal(t, at)
+		typ := "signal.opened"
+		switch {
+		case !open:
+			sig = newSignal(rng, t, at)
+			sig.ID = fmt.Sprintf("sig_%s_%d", strings.ToLower(t.Symbol), n)
+			sig.ExpiresAt = isoTime(at.Add(24 * time.Hour))
+			sig.TrailingStopStatus = "inactive"
+		case rng.Float64() < 0.7:
+			typ = "signal.updated"
+			sig.MaxProfitPct = round(sig.MaxProfitPct+rng.Float64(), 2)
+			sig.TrailingStopStatus = []string{"armed", "trailing"}[rng.Intn(2)]
+		default:
+			typ = "signal.closed"
+			sig.OutcomeStatus = []string{"target_hit", "stopped_out", "expired"}[rng.Intn(3)]
+			sig.ExitTime = isoTime(at)
+		}
+		return typ, sig, sig.ConfidenceScore >= minConf
+	})
+}
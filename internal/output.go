@@ -1,11 +1,13 @@
 package internal
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -24,14 +26,69 @@ var (
 
 // PrintJSON pretty-prints raw JSON bytes.
 func PrintJSON(data []byte) {
-	var v interface{}
-	if err := json.Unmarshal(data, &v); err != nil {
+	PrintJSONMeta(data, Meta{})
+}
+
+// PrintJSONMeta pretty-prints raw JSON bytes, adding meta as a "_meta" key
+// when the payload is an object. The bytes are re-indented as they are, not
+// decoded, so key order follows the API. Arrays cannot carry meta, so any
+// staleness is reported on stderr instead (see PrintStaleMeta).
+func PrintJSONMeta(data []byte, meta Meta) {
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(data)/4)
+	if err := json.Indent(&buf, bytes.TrimSpace(data), "", "  "); err != nil {
 		os.Stdout.Write(data)
 		return
 	}
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	enc.Encode(v) //nolint:errcheck
+	out := buf.Bytes()
+	if !meta.IsZero() {
+		if out[0] == '{' {
+			out = spliceMeta(out, meta)
+		} else {
+			PrintStaleMeta(meta)
+		}
+	}
+	os.Stdout.Write(append(out, '\n')) //nolint:errcheck
+}
+
+// PrintStaleMeta reports stale data whose JSON output has no room for
+// "_meta" as a {"_meta": {...}} line on stderr, the way errors are
+// reported in JSON mode. It does nothing for fresh data.
+func PrintStaleMeta(meta Meta) {
+	if meta.StaleSince == nil {
+		return
+	}
+	json.NewEncoder(os.Stderr).Encode(map[string]Meta{"_meta": meta}) //nolint:errcheck
+}
+
+// spliceMeta inserts a "_meta" member before the closing brace of obj, an
+// object already indented by json.Indent.
+func spliceMeta(obj []byte, meta Meta) []byte {
+	m, err := json.MarshalIndent(meta, "  ", "  ")
+	if err != nil {
+		return obj
+	}
+	body := bytes.TrimRight(obj[:len(obj)-1], "\n")
+	sep := ",\n  "
+	if len(bytes.TrimSpace(body)) == 1 { // "{}"
+		sep = "\n  "
+	}
+	out := make([]byte, 0, len(body)+len(sep)+len(m)+16)
+	out = append(out, body...)
+	out = append(out, sep...)
+	out = append(out, `"_meta": `...)
+	out = append(out, m...)
+	return append(out, "\n}"...)
+}
+
+// StaleNotice returns a footer line describing stale data, or "" if meta is fresh.
+func StaleNotice(meta Meta) string {
+	if meta.StaleSince == nil {
+		return ""
+	}
+	age := time.Since(*meta.StaleSince).Round(time.Second)
+	return Yellow.Sprintf("⚠ stale data from %s (%s ago, %s)",
+		meta.StaleSince.Local().Format("2006-01-02 15:04"), age, meta.StaleReason)
 }
 
 // Table is a simple tab-aligned table writer.
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ProjectConfigName is the project config file, looked up from the working
+// directory upwards.
+const ProjectConfigName = ".fti.toml"
+
+// ftiDirs returns where fti keeps its files: config holds config.toml and
+// secrets.enc, state the response cache, rate limits and endpoint health.
+// In order:
+//
+//  1. $FTI_HOME, for both
+//  2. ~/.fti, for both, if it exists
+//  3. $XDG_CONFIG_HOME/fti and $XDG_CACHE_HOME/fti, if either is set
+//     (unset ones default to ~/.config and ~/.cache)
+//  4. ~/.fti, for both
+func ftiDirs() (config, state string, err error) {
+	if v := os.Getenv("FTI_HOME"); v != "" {
+		return v, v, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	legacy := filepath.Join(home, ".fti")
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, legacy, nil
+	}
+
+	xdgConfig, xdgCache := os.Getenv("XDG_CONFIG_HOME"), os.Getenv("XDG_CACHE_HOME")
+	if xdgConfig == "" && xdgCache == "" {
+		return legacy, legacy, nil
+	}
+	if xdgConfig == "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+	if xdgCache == "" {
+		xdgCache = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(xdgConfig, "fti"), filepath.Join(xdgCache, "fti"), nil
+}
+
+// ConfigDir returns the directory holding config.toml; see ftiDirs.
+func ConfigDir() (string, error) {
+	dir, _, err := ftiDirs()
+	return dir, err
+}
+
+// stateDir returns the directory for the cache and state shared between
+// fti processes; see ftiDirs.
+func stateDir() (string, error) {
+	_, dir, err := ftiDirs()
+	return dir, err
+}
+
+// ProjectConfigPath returns the nearest .fti.toml in the working directory
+// or one of its parents, or "" if there is none.
+func ProjectConfigPath() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
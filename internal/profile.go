@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Profile is a named set of connection settings in config.toml, e.g.
+//
+//	[profiles.staging]
+//	api_key = "ti_test_..."
+//	api_url = "https://staging.example"
+//
+// Settings a profile leaves empty fall back to the top-level ones.
+type Profile struct {
+	APIKey      string   `toml:"api_key,omitempty"`
+	APIKeyCmd   string   `toml:"api_key_cmd,omitempty"`
+	APIKeyStore string   `toml:"api_key_store,omitempty"`
+	APIURL      string   `toml:"api_url,omitempty"`
+	APIURLs     []string `toml:"api_urls,omitempty"`
+}
+
+// ProfileFlag is the value of the global --profile flag.
+var ProfileFlag string
+
+// ActiveProfile returns the profile selected by --profile, FTI_PROFILE or
+// default_profile, in that order, and where the choice came from. The
+// name is "" when no profile is selected.
+func ActiveProfile(cfg Config) (name, origin string) {
+	switch {
+	case ProfileFlag != "":
+		return ProfileFlag, "flag"
+	case os.Getenv("FTI_PROFILE") != "":
+		return os.Getenv("FTI_PROFILE"), "env"
+	case cfg.DefaultProfile != "":
+		return cfg.DefaultProfile, "file"
+	}
+	return "", ""
+}
+
+// applyProfile returns cfg with the active profile's settings in place of
+// the top-level ones. Selecting a profile that is not defined is an error.
+func applyProfile(cfg Config) (Config, error) {
+	name, origin := ActiveProfile(cfg)
+	if name == "" {
+		return cfg, nil
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		err := fmt.Errorf("profile %q is not defined in config.toml%s", name, profileList(cfg))
+		if origin == "flag" {
+			return cfg, &UsageError{Err: err}
+		}
+		return cfg, err
+	}
+
+	cfg.Profile = name
+	// The key sources replace each other, so a profile with api_key_cmd
+	// does not fall back to a top-level api_key.
+	if p.APIKey != "" || p.APIKeyCmd != "" || p.APIKeyStore != "" {
+		cfg.APIKey, cfg.APIKeyCmd, cfg.APIKeyStore = p.APIKey, p.APIKeyCmd, p.APIKeyStore
+	}
+	if p.APIURL != "" || len(p.APIURLs) > 0 {
+		cfg.APIURL, cfg.APIURLs = p.APIURL, p.APIURLs
+	}
+	return cfg, nil
+}
+
+// profileList describes the defined profiles for an error message.
+func profileList(cfg Config) string {
+	if len(cfg.Profiles) == 0 {
+		return " (it has no [profiles.<name>] sections)"
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return " (defined: " + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestActiveProfileOrder(t *testing.T) {
+	defer func(saved string) { ProfileFlag = saved }(ProfileFlag)
+
+	tests := []struct {
+		flag, env, file string
+		name, origin    string
+	}{
+		{"a", "b", "c", "a", "flag"},
+		{"", "b", "c", "b", "env"},
+		{"", "", "c", "c", "file"},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		ProfileFlag = tt.flag
+		t.Setenv("FTI_PROFILE", tt.env)
+		name, origin := ActiveProfile(Config{DefaultProfile: tt.file})
+		if name != tt.name || origin != tt.origin {
+			t.Errorf("flag %q, env %q, file %q: ActiveProfile = %q, %q; want %q, %q",
+				tt.flag, tt.env, tt.file, name, origin, tt.name, tt.origin)
+		}
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	defer func(saved string) { ProfileFlag = saved }(ProfileFlag)
+	t.Setenv("FTI_PROFILE", "")
+
+	cfg := Config{
+		APIKey: "ti_live_top",
+		APIURL: "https://top.example",
+		Profiles: map[string]Profile{
+			"staging": {APIKeyCmd: "pass fti/staging", APIURLs: []string{"https://a.example", "https://b.example"}},
+			"bare":    {},
+		},
+	}
+
+	ProfileFlag = "staging"
+	got, err := applyProfile(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The profile's key command and URLs replace the top-level key and URL
+	// rather than falling back to them.
+	if got.Profile != "staging" || got.APIKey != "" || got.APIKeyCmd != "pass fti/staging" ||
+		got.APIURL != "" || !reflect.DeepEqual(got.APIURLs, []string{"https://a.example", "https://b.example"}) {
+		t.Errorf("applyProfile(staging) = %+v", got)
+	}
+
+	ProfileFlag = "bare"
+	if got, err = applyProfile(cfg); err != nil || got.APIKey != "ti_live_top" || got.APIURL != "https://top.example" {
+		t.Errorf("empty profile: %+v, %v; want the top-level settings", got, err)
+	}
+
+	ProfileFlag = "nosuch"
+	_, err = applyProfile(cfg)
+	var usage *UsageError
+	if !errors.As(err, &usage) {
+		t.Errorf("undefined --profile: %v, want a usage error", err)
+	}
+}
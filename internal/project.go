@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// projectDenied are config keys a .fti.toml may not set. The file comes
+// with a repository, so it must not choose the API key, run commands, or
+// route requests through a proxy or TLS setup of its choosing.
+var projectDenied = map[string]bool{
+	"api_key":              true,
+	"api_key_cmd":          true,
+	"api_key_store":        true,
+	"profiles":             true,
+	"trusted_projects":     true,
+	"proxy":                true,
+	"ca_bundle":            true,
+	"client_cert":          true,
+	"client_key":           true,
+	"insecure_skip_verify": true,
+}
+
+// projectDeniedFlags are flags a .fti.toml's [defaults] may not set, on
+// top of the flag forms of projectDenied: they choose where the API key is
+// stored or shown, or which file a trace is written to.
+var projectDeniedFlags = map[string]bool{
+	"store":      true,
+	"reveal":     true,
+	"trace-file": true,
+}
+
+// projectURLKeys may only be set by a project the user trusts (see
+// TrustsProject), since requests carry the user's API key to that server.
+var projectURLKeys = map[string]bool{"api_url": true, "api_urls": true}
+
+// projectConfig is a parsed .fti.toml.
+type projectConfig struct {
+	path string
+	cfg  Config
+	md   toml.MetaData
+}
+
+// readProjectConfig finds and parses the project's .fti.toml, returning nil
+// if there is none. It is read like config.toml, with the same keys.
+func readProjectConfig() (*projectConfig, error) {
+	path, err := ProjectConfigPath()
+	if err != nil || path == "" {
+		return nil, err
+	}
+	cfg, md, err := readConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var denied []string
+	for key := range projectDenied {
+		if md.IsDefined(key) {
+			denied = append(denied, key)
+		}
+	}
+	denied = append(denied, stripDeniedDefaults(cfg.Defaults, "defaults")...)
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		warnOnce("project:"+path, "ignoring %s in %s; only your own config can set the API key, proxy, TLS or trace options",
+			strings.Join(denied, ", "), path)
+	}
+	return &projectConfig{path: path, cfg: cfg, md: md}, nil
+}
+
+// stripDeniedDefaults removes the flags a project may not set from its
+// [defaults] table and the subcommand tables within, returning their names.
+func stripDeniedDefaults(table map[string]interface{}, section string) []string {
+	var denied []string
+	for key, v := range table {
+		if sub, ok := v.(map[string]interface{}); ok {
+			denied = append(denied, stripDeniedDefaults(sub, section+"."+key)...)
+			continue
+		}
+		flag := strings.ReplaceAll(key, "_", "-")
+		if projectDeniedFlags[flag] || projectDenied[strings.ReplaceAll(flag, "-", "_")] {
+			delete(table, key)
+			denied = append(denied, section+"."+key)
+		}
+	}
+	return denied
+}
+
+// applyProject returns cfg with the settings p defines in place of its
+// own, so a repository can pin e.g. its timeout. Its API URL is only used
+// once the user trusts the project; like a profile, a project that sets
+// api_url or api_urls then replaces both.
+func applyProject(cfg Config, p *projectConfig) Config {
+	if p == nil {
+		return cfg
+	}
+	cfg.ProjectFile = p.path
+	cfg.projectKeys = map[string]bool{}
+	useURLs := false
+	if p.md.IsDefined("api_url") || p.md.IsDefined("api_urls") {
+		if useURLs = cfg.TrustsProject(filepath.Dir(p.path)); useURLs {
+			cfg.APIURL, cfg.APIURLs = "", nil
+			cfg.projectKeys["api_url"], cfg.projectKeys["api_urls"] = true, true
+		} else {
+			warnOnce("untrusted:"+p.path, "ignoring the API URL in %s, which would receive your API key; if you trust this project, run: fti config trust", p.path)
+		}
+	}
+
+	dst, src := reflect.ValueOf(&cfg).Elem(), reflect.ValueOf(p.cfg)
+	for _, key := range ConfigKeys() {
+		if projectDenied[key] || projectURLKeys[key] && !useURLs || !p.md.IsDefined(key) {
+			continue
+		}
+		fieldByTOML(dst, key).Set(fieldByTOML(src, key))
+		cfg.projectKeys[key] = true
+	}
+	cfg.Defaults = mergeDefaults(cfg.Defaults, p.cfg.Defaults)
+	return cfg
+}
+
+// mergeDefaults returns the [defaults] tables of base with over's entries
+// in place of base's, merging subcommand tables recursively.
+func mergeDefaults(base, over map[string]interface{}) map[string]interface{} {
+	if len(over) == 0 {
+		return base
+	}
+	out := make(map[string]interface{}, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		sub, isTable := v.(map[string]interface{})
+		baseSub, baseIsTable := out[k].(map[string]interface{})
+		if isTable && baseIsTable {
+			v = mergeDefaults(baseSub, sub)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// FlagDefaults returns the [defaults] sections of config.toml and the
+// project's .fti.toml, the project's winning. They do not depend on the
+// profile, so they can be read before flags are parsed.
+func FlagDefaults() (map[string]interface{}, error) {
+	cfg, err := ReadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	proj, err := readProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	return applyProject(cfg, proj).Defaults, nil
+}
+
+// TrustsProject reports whether dir, a directory holding a .fti.toml, is
+// listed in trusted_projects.
+func (cfg Config) TrustsProject(dir string) bool {
+	dir = CanonicalDir(dir)
+	for _, d := range cfg.TrustedProjects {
+		if CanonicalDir(d) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// CanonicalDir returns dir as an absolute path with symlinks resolved, so
+// trusted_projects entries compare equal however the directory was reached.
+func CanonicalDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	return filepath.Clean(dir)
+}
+
+// FromProject reports whether key's value came from the project's .fti.toml.
+func (cfg Config) FromProject(key string) bool {
+	return cfg.projectKeys[key]
+}
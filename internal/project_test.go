@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectConfigCannotRedirectKey(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_API_URL", "")
+	proj := t.TempDir()
+	data := `api_url = "https://evil.example"
+api_key_cmd = "echo pwned"
+proxy = "http://evil.example:3128"
+ca_bundle = "/tmp/evil.pem"
+insecure_skip_verify = true
+trusted_projects = ["` + proj + `"]
+timeout = "3s"
+
+[defaults]
+trace-file = "/tmp/clobbered.har"
+proxy = "http://evil.example:3128"
+insecure_skip_verify = true
+api-key = "ti_evil"
+retries = 1
+
+[defaults.auth.login]
+store = "file"
+
+[defaults.whales]
+hours = 6
+`
+	if err := os.WriteFile(filepath.Join(proj, ProjectConfigName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd) //nolint:errcheck
+	if err := os.Chdir(proj); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(Config{APIURL: "https://api.example", Proxy: "http://corp:3128"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Timeout != "3s" || !cfg.FromProject("timeout") {
+		t.Errorf("timeout = %q, want the project's 3s", cfg.Timeout)
+	}
+	defaults, err := FlagDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, flag := range []string{"trace-file", "proxy", "insecure_skip_verify", "api-key"} {
+		if _, ok := defaults[flag]; ok {
+			t.Errorf("project [defaults] set --%s", flag)
+		}
+	}
+	if login, _ := defaults["auth"].(map[string]interface{})["login"].(map[string]interface{}); login["store"] != nil {
+		t.Errorf("project [defaults.auth.login] set --store")
+	}
+	if defaults["retries"] != int64(1) || defaults["whales"].(map[string]interface{})["hours"] != int64(6) {
+		t.Errorf("project [defaults] lost harmless flags: %v", defaults)
+	}
+
+	if cfg.APIURL != "https://api.example" || cfg.APIKeyCmd != "" || cfg.Proxy != "http://corp:3128" ||
+		cfg.CABundle != "" || cfg.InsecureSkipVerify || len(cfg.TrustedProjects) != 0 {
+		t.Errorf("untrusted project changed protected settings: %+v", cfg)
+	}
+
+	if err := UpdateConfig(func(c *Config) error {
+		c.TrustedProjects = []string{proj}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIURL != "https://evil.example" || !cfg.FromProject("api_url") {
+		t.Errorf("api_url = %q, want the trusted project's", cfg.APIURL)
+	}
+	if cfg.Proxy != "http://corp:3128" || cfg.APIKeyCmd != "" {
+		t.Errorf("trusted project still may not set proxy or key: %+v", cfg)
+	}
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// RateLimiter is a token bucket enforcing the API key's per-minute quota,
+// learned from the X-RateLimit-* headers of the key's responses. Its state
+// lives in ~/.fti/ratelimit.json and is guarded by a lock file, so
+// concurrent fti processes using the same key draw from one bucket.
+type RateLimiter struct {
+	statePath string
+	lockPath  string
+	key       string
+
+	// Notice receives a message whenever a request is held back. Defaults to stderr.
+	Notice io.Writer
+}
+
+type bucket struct {
+	Limit   int       `json:"limit_per_minute"`
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// NewRateLimiter returns a limiter for apiKey backed by ~/.fti. Requests
+// without a key share an anonymous bucket.
+func NewRateLimiter(apiKey string) (*RateLimiter, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimiter{
+		statePath: filepath.Join(dir, "ratelimit.json"),
+		lockPath:  filepath.Join(dir, "ratelimit.lock"),
+		key:       keyFingerprint(apiKey),
+		Notice:    os.Stderr,
+	}, nil
+}
+
+// keyFingerprint identifies an API key without storing it.
+func keyFingerprint(apiKey string) string {
+	if apiKey == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Wait reserves one request from the bucket, sleeping if the quota for the
+// current minute is used up. It does nothing while the limit is unknown.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	var wait time.Duration
+	var limit int
+	err := l.update(func(b *bucket, now time.Time) bool {
+		if b.Limit <= 0 {
+			return false
+		}
+		refill(b, now)
+		b.Tokens--
+		if b.Tokens < 0 {
+			wait = time.Duration(-b.Tokens * float64(time.Minute) / float64(b.Limit))
+			limit = b.Limit
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		fmt.Fprintf(l.Notice, "%s: rate limit of %d req/min reached, waiting %s\n",
+			Yellow.Sprint("throttled"), limit, wait.Round(100*time.Millisecond))
+		return sleepCtx(ctx, wait)
+	}
+	return nil
+}
+
+// SetLimit records the key's quota, e.g. as shown by fti auth me.
+func (l *RateLimiter) SetLimit(perMinute int) error {
+	if perMinute <= 0 {
+		return nil
+	}
+	return l.update(func(b *bucket, now time.Time) bool {
+		setLimit(b, perMinute, now)
+		return true
+	})
+}
+
+// Observe updates the bucket from X-RateLimit-Limit / X-RateLimit-Remaining
+// response headers, treating the server's view as authoritative.
+func (l *RateLimiter) Observe(h http.Header) error {
+	limit, errL := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, errR := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if errL != nil && errR != nil {
+		return nil
+	}
+	return l.update(func(b *bucket, now time.Time) bool {
+		if errL == nil && limit > 0 {
+			setLimit(b, limit, now)
+		}
+		if errR == nil && b.Limit > 0 {
+			refill(b, now)
+			b.Tokens = math.Min(b.Tokens, float64(remaining))
+		}
+		return true
+	})
+}
+
+// setLimit changes the bucket's quota. A bucket seeing its first limit
+// starts full; an existing one keeps its tokens, clamped to the new limit.
+func setLimit(b *bucket, perMinute int, now time.Time) {
+	refill(b, now)
+	if b.Limit == 0 {
+		b.Tokens = float64(perMinute)
+	}
+	b.Limit = perMinute
+	b.Tokens = math.Min(b.Tokens, float64(perMinute))
+}
+
+// refill adds the tokens accrued since the last update, up to the limit.
+func refill(b *bucket, now time.Time) {
+	if !b.Updated.IsZero() && b.Limit > 0 {
+		elapsed := now.Sub(b.Updated).Minutes()
+		b.Tokens = math.Min(float64(b.Limit), b.Tokens+elapsed*float64(b.Limit))
+	}
+	b.Updated = now
+}
+
+// update runs fn on this key's bucket under the lock, saving the state file
+// when fn reports a change.
+func (l *RateLimiter) update(fn func(b *bucket, now time.Time) bool) error {
+	unlock, err := lockFile(l.lockPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state := map[string]*bucket{}
+	if data, err := os.ReadFile(l.statePath); err == nil {
+		json.Unmarshal(data, &state) //nolint:errcheck
+	}
+	b := state[l.key]
+	if b == nil {
+		b = &bucket{}
+		state[l.key] = b
+	}
+
+	if !fn(b, time.Now()) {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.statePath, data, 0600)
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitLearnedFromHeaders(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "ti_test_key")
+	l, err := NewRateLimiter(c.APIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notice bytes.Buffer
+	l.Notice = &notice
+	c.Limiter = l
+
+	if _, err := c.Get(context.Background(), "/api/tokens", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if len(paths) != 1 || paths[0] != "/api/tokens" {
+		t.Errorf("requests = %q, want only /api/tokens", paths)
+	}
+	mu.Unlock()
+
+	// The headers used up the quota, so the next request is held back.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Error("Wait did not throttle after X-RateLimit-Remaining: 0")
+	}
+	if !strings.Contains(notice.String(), "rate limit of 60 req/min") {
+		t.Errorf("notice = %q", notice.String())
+	}
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+)
+
+// UserAgent returns the User-Agent sent by fti version, e.g.
+// "fti-cli/1.4.0 (darwin; arm64; go1.22.5)".
+func UserAgent(version string) string {
+	return fmt.Sprintf("fti-cli/%s (%s; %s; %s)", version, runtime.GOOS, runtime.GOARCH, runtime.Version())
+}
+
+// newRequestID returns a random UUID (version 4) for X-Request-ID.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:]) //nolint:errcheck
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// setClientHeaders sets the headers common to every request: credentials,
+// User-Agent and a new X-Request-ID, which it returns.
+func (c *Client) setClientHeaders(req *http.Request) string {
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	id := newRequestID()
+	req.Header.Set("X-Request-ID", id)
+	return id
+}
+
+// withRequestID records id on err if it is one of the client's error types
+// that does not carry one yet, so the message names the request.
+func withRequestID(err error, id string) error {
+	var apiErr *APIError
+	var netErr *NetworkError
+	var sizeErr *ResponseTooLargeError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = id
+		}
+	case errors.As(err, &netErr):
+		if netErr.RequestID == "" {
+			netErr.RequestID = id
+		}
+	case errors.As(err, &sizeErr):
+		if sizeErr.RequestID == "" {
+			sizeErr.RequestID = id
+		}
+	}
+	return err
+}
+
+// RequestIDOf returns the X-Request-ID recorded on err, if any.
+func RequestIDOf(err error) string {
+	var apiErr *APIError
+	var netErr *NetworkError
+	var sizeErr *ResponseTooLargeError
+	var schemaErr *SchemaError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.RequestID
+	case errors.As(err, &netErr):
+		return netErr.RequestID
+	case errors.As(err, &sizeErr):
+		return sizeErr.RequestID
+	case errors.As(err, &schemaErr):
+		return schemaErr.RequestID
+	}
+	return ""
+}
+
+// requestIDSuffix formats id for the end of an error message.
+func requestIDSuffix(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (request id " + id + ")"
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry defaults used when neither flags nor config.toml override them.
+const (
+	DefaultRetries      = 3
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// retryableError marks a transient failure (network error, 429 or 5xx) that
+// may succeed if the request is sent again.
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// isIdempotent reports whether a request with the given method is safe to resend.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether an HTTP status is worth retrying.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// backoff returns the delay before retry n (0-based): exponential growth from
+// retryBaseDelay with full jitter, capped at max.
+func backoff(n int, max time.Duration) time.Duration {
+	d := retryBaseDelay << uint(n)
+	if d <= 0 || d > max {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// parseRetryAfter parses a Retry-After header given either as delta-seconds
+// or as an HTTP date. It returns 0 if the header is absent or malformed.
+func parseRetryAfter(h string, now time.Time) time.Duration {
+	h = strings.TrimSpace(h)
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepCtx waits for d or until ctx is done, whichever comes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,292 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schemas holds the response shapes this build of fti expects, one file
+// per endpoint. They use a small subset of JSON Schema: type (a name or a
+// list of names), properties, required and items.
+//
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// schemaSampleItems bounds how many elements of each array are checked.
+// Drift affects every element alike, so a sample finds it without walking
+// large history or whale payloads.
+const schemaSampleItems = 25
+
+// schemaRoutes maps API paths to schema names. A trailing slash matches
+// any path below it.
+var schemaRoutes = []struct{ path, name string }{
+	{"/api/tokens", "tokens"},
+	{"/api/tokens/", "token"},
+	{"/api/history/price/", "price_history"},
+	{"/api/whales/combined", "whales"},
+	{"/api/v1/signals/active", "signals_active"},
+	{"/api/v1/signals/history", "signals_history"},
+	{"/api/matches/upcoming", "matches"},
+	{"/api/v1/auth/me", "auth_me"},
+	{"/api/v1/auth/register", "auth_register"},
+}
+
+type schema struct {
+	Type       schemaTypes        `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *schema            `json:"items"`
+}
+
+// schemaTypes accepts "type" as either a string or a list of strings.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var one string
+	if json.Unmarshal(b, &one) == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+var (
+	schemaMu    sync.Mutex
+	schemaCache = map[string]*schema{}
+)
+
+// schemaFor returns the expected schema for path, or nil if the endpoint
+// has none.
+func schemaFor(path string) (string, *schema) {
+	name := ""
+	for _, r := range schemaRoutes {
+		if path == r.path || strings.HasSuffix(r.path, "/") && strings.HasPrefix(path, r.path) {
+			name = r.name
+			break
+		}
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	if s, ok := schemaCache[name]; ok {
+		return name, s
+	}
+	var s *schema
+	if data, err := schemas.ReadFile("schemas/" + name + ".json"); err == nil {
+		s = new(schema)
+		if json.Unmarshal(data, s) != nil {
+			s = nil
+		}
+	}
+	schemaCache[name] = s
+	return name, s
+}
+
+// ValidateResponse checks body against the schema expected for path. It
+// returns nil when the body matches or the endpoint has no schema.
+func ValidateResponse(method, path string, body []byte) *SchemaError {
+	name, s := schemaFor(path)
+	if s == nil {
+		return nil
+	}
+	v := &validator{seen: map[string]bool{}}
+	v.check(s, bytes.TrimSpace(body), "")
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &SchemaError{Method: method, Endpoint: path, Schema: name, Problems: v.problems}
+}
+
+// validator collects problems, reporting each once even when it recurs in
+// every element of an array.
+type validator struct {
+	problems []string
+	seen     map[string]bool
+}
+
+func (v *validator) add(at, format string, args ...interface{}) {
+	if at == "" {
+		at = "response"
+	}
+	p := at + ": " + fmt.Sprintf(format, args...)
+	if !v.seen[p] {
+		v.seen[p] = true
+		v.problems = append(v.problems, p)
+	}
+}
+
+func (v *validator) check(s *schema, raw json.RawMessage, at string) {
+	kind := jsonKind(raw)
+	if len(s.Type) > 0 && !typeAllowed(s.Type, kind, raw) {
+		v.add(at, "expected %s, got %s", strings.Join(s.Type, " or "), kind)
+		return
+	}
+
+	switch kind {
+	case "object":
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			v.add(at, "malformed object")
+			return
+		}
+		var missing []string
+		for _, f := range s.Required {
+			if _, ok := obj[f]; !ok {
+				missing = append(missing, f)
+			}
+		}
+		for _, f := range missing {
+			if k := renamedTo(f, obj, s.Properties); k != "" {
+				v.add(fieldPath(at, f), "missing (renamed to %q?)", k)
+			} else {
+				v.add(fieldPath(at, f), "missing")
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if val, ok := obj[name]; ok {
+				v.check(s.Properties[name], val, fieldPath(at, name))
+			}
+		}
+	case "array":
+		if s.Items == nil {
+			return
+		}
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			v.add(at, "malformed array")
+			return
+		}
+		for _, it := range items[:min(len(items), schemaSampleItems)] {
+			v.check(s.Items, it, at+"[]")
+		}
+	}
+}
+
+// renamedTo returns the unknown key in obj that most likely replaced the
+// missing field f: the same name once case, "_" and "-" are ignored, or
+// one containing the other.
+func renamedTo(f string, obj map[string]json.RawMessage, known map[string]*schema) string {
+	norm := func(s string) string {
+		return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(s))
+	}
+	nf := norm(f)
+	var keys []string
+	for k := range obj {
+		if _, ok := known[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if nk := norm(k); nk == nf || strings.Contains(nk, nf) || strings.Contains(nf, nk) {
+			return k
+		}
+	}
+	return ""
+}
+
+func fieldPath(at, field string) string {
+	if at == "" {
+		return field
+	}
+	return at + "." + field
+}
+
+// jsonKind names the JSON type of raw from its first byte.
+func jsonKind(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "nothing"
+	}
+	switch raw[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+func typeAllowed(types schemaTypes, kind string, raw json.RawMessage) bool {
+	for _, t := range types {
+		switch {
+		case t == kind:
+			return true
+		case t == "integer" && kind == "number" && !bytes.ContainsAny(raw, ".eE"):
+			return true
+		}
+	}
+	return false
+}
+
+// driftWarnInterval is how often the same schema drift is warned about.
+const driftWarnInterval = 24 * time.Hour
+
+// ShouldWarnDrift reports whether e is worth a warning: the first time an
+// endpoint drifts in a given way, then at most once per driftWarnInterval.
+// The record is kept in the state directory and shared by every fti
+// process, so an agent running fti in a loop is not warned on each run.
+func ShouldWarnDrift(e *SchemaError) bool {
+	dir, err := stateDir()
+	if err != nil {
+		return true
+	}
+	unlock, err := lockFile(filepath.Join(dir, "schema-warnings.lock"))
+	if err != nil {
+		return true
+	}
+	defer unlock()
+
+	path := filepath.Join(dir, "schema-warnings.json")
+	warned := map[string]time.Time{}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &warned) //nolint:errcheck
+	}
+	problems := append([]string(nil), e.Problems...)
+	sort.Strings(problems)
+	sum := sha256.Sum256([]byte(strings.Join(problems, "\n")))
+	key := e.Schema + ":" + hex.EncodeToString(sum[:8])
+
+	now := time.Now()
+	if last, ok := warned[key]; ok && now.Sub(last) < driftWarnInterval {
+		return false
+	}
+	for k, t := range warned {
+		if now.Sub(t) >= driftWarnInterval {
+			delete(warned, k)
+		}
+	}
+	warned[key] = now
+	if data, err := json.Marshal(warned); err == nil {
+		os.WriteFile(path, data, 0600) //nolint:errcheck
+	}
+	return true
+}
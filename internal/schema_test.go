@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShouldWarnDrift(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FTI_HOME", home)
+
+	renamed := &SchemaError{Schema: "tokens", Problems: []string{`[].health_score: missing (renamed to "healthScore"?)`}}
+	if !ShouldWarnDrift(renamed) {
+		t.Fatal("first drift not warned about")
+	}
+	if ShouldWarnDrift(renamed) {
+		t.Error("the same drift warned about twice within a day")
+	}
+	other := &SchemaError{Schema: "tokens", Problems: []string{"[].price: want number, got string"}}
+	if !ShouldWarnDrift(other) {
+		t.Error("a different drift of the same schema not warned about")
+	}
+
+	// A day later the warning is repeated.
+	path := filepath.Join(home, "schema-warnings.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var warned map[string]time.Time
+	if err := json.Unmarshal(data, &warned); err != nil {
+		t.Fatal(err)
+	}
+	for k := range warned {
+		warned[k] = time.Now().Add(-25 * time.Hour)
+	}
+	data, _ = json.Marshal(warned)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !ShouldWarnDrift(renamed) {
+		t.Error("drift not warned about again after a day")
+	}
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Key stores that can hold the API key instead of config.toml, selected
+// with api_key_store.
+const (
+	StoreKeyring = "keyring" // the OS keyring, through its command-line tool
+	StoreFile    = "file"    // ~/.fti/secrets.enc, encrypted with a passphrase
+)
+
+// keyCmdTimeout bounds api_key_cmd. It is generous because password
+// managers may wait for the user to unlock them.
+const keyCmdTimeout = 2 * time.Minute
+
+// ErrSecretNotFound is returned by a KeyStore that holds no key for an account.
+var ErrSecretNotFound = errors.New("no API key stored")
+
+// KeyStore keeps API keys outside config.toml, one per account. The
+// account is the profile name, or "default" without a profile.
+type KeyStore interface {
+	Get(account string) (string, error)
+	Set(account, key string) error
+}
+
+// OpenKeyStore returns the store named by api_key_store.
+func OpenKeyStore(name string) (KeyStore, error) {
+	switch name {
+	case StoreKeyring:
+		return newKeyring()
+	case StoreFile:
+		return newSecretsFile()
+	}
+	return nil, fmt.Errorf("unknown api_key_store %q (use %q or %q)", name, StoreKeyring, StoreFile)
+}
+
+// KeyAccount names the KeyStore account for profile.
+func KeyAccount(profile string) string {
+	if profile == "" {
+		return "default"
+	}
+	return profile
+}
+
+// ConfigAPIKey returns the API key configured in cfg (with its profile
+// applied) and where it came from: "api_key_cmd", "keyring", "secrets
+// file" or "file". api_key_cmd is tried first, then api_key_store, then
+// the plaintext api_key.
+func ConfigAPIKey(cfg Config) (key, source string, err error) {
+	switch {
+	case cfg.APIKeyCmd != "":
+		key, err = runKeyCommand(cfg.APIKeyCmd)
+		return key, "api_key_cmd", err
+	case cfg.APIKeyStore != "":
+		ks, err := OpenKeyStore(cfg.APIKeyStore)
+		if err != nil {
+			return "", cfg.APIKeyStore, err
+		}
+		source := map[string]string{StoreKeyring: "keyring", StoreFile: "secrets file"}[cfg.APIKeyStore]
+		key, err = ks.Get(KeyAccount(cfg.Profile))
+		if errors.Is(err, ErrSecretNotFound) {
+			err = fmt.Errorf("%w in the %s for %q — run: fti auth login", err, source, KeyAccount(cfg.Profile))
+		}
+		return key, source, err
+	}
+	return cfg.APIKey, "file", nil
+}
+
+// runKeyCommand runs cmdline through the shell and returns the first line
+// it prints. Its stderr is passed through, so helpers can prompt.
+func runKeyCommand(cmdline string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyCmdTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", cmdline)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", cmdline)
+	}
+	var out bytes.Buffer
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, &out, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("api_key_cmd failed: %w", err)
+	}
+	key, _, _ := strings.Cut(strings.TrimSpace(out.String()), "\n")
+	if key = strings.TrimSpace(key); key == "" {
+		return "", errors.New("api_key_cmd printed no API key")
+	}
+	return key, nil
+}
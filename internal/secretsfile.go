@@ -0,0 +1,210 @@
+package internal
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// secretsIterations is the PBKDF2-HMAC-SHA256 work factor for new
+	// files; existing files record their own.
+	secretsIterations = 600_000
+	// secretsMaxIterations bounds the work factor read from a file, so a
+	// tampered file cannot make every command hang.
+	secretsMaxIterations = 10 * secretsIterations
+	secretsKDF           = "pbkdf2-sha256"
+	secretsAAD           = "fti-secrets-v1"
+)
+
+// secretsFile keeps API keys in ~/.fti/secrets.enc, encrypted with
+// AES-256-GCM under a key derived from a passphrase. The passphrase comes
+// from FTI_PASSPHRASE or is asked for on the terminal.
+type secretsFile struct {
+	path       string
+	lockPath   string
+	passphrase string
+}
+
+// secretsEnvelope is the on-disk format. Byte fields are base64 in JSON.
+type secretsEnvelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func newSecretsFile() (*secretsFile, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &secretsFile{
+		path:     filepath.Join(dir, "secrets.enc"),
+		lockPath: filepath.Join(dir, "secrets.lock"),
+	}, nil
+}
+
+func (s *secretsFile) Get(account string) (string, error) {
+	keys, err := s.load(false)
+	if err != nil {
+		return "", err
+	}
+	key, ok := keys[account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return key, nil
+}
+
+func (s *secretsFile) Set(account, key string) error {
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	keys, err := s.load(true)
+	if err != nil {
+		return err
+	}
+	keys[account] = key
+	return s.save(keys)
+}
+
+// load decrypts the file. A missing file is empty; creating says a new
+// one is about to be written, so the passphrase is asked for twice.
+func (s *secretsFile) load(creating bool) (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		if !creating {
+			return nil, ErrSecretNotFound
+		}
+		if err := s.askPassphrase(true); err != nil {
+			return nil, err
+		}
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading secrets file: %w", err)
+	}
+
+	var env secretsEnvelope
+	if err := json.Unmarshal(data, &env); err != nil || env.KDF != secretsKDF || env.Iterations <= 0 || env.Iterations > secretsMaxIterations {
+		return nil, fmt.Errorf("%s is not a valid fti secrets file", s.path)
+	}
+	if err := s.askPassphrase(false); err != nil {
+		return nil, err
+	}
+	gcm, err := secretsCipher(s.passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, env.Nonce, env.Ciphertext, []byte(secretsAAD))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt %s: wrong passphrase or damaged file", s.path)
+	}
+	keys := map[string]string{}
+	if err := json.Unmarshal(plain, &keys); err != nil {
+		return nil, fmt.Errorf("reading secrets file: %w", err)
+	}
+	return keys, nil
+}
+
+// save encrypts keys with a fresh salt and nonce and replaces the file.
+func (s *secretsFile) save(keys map[string]string) error {
+	plain, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	env := secretsEnvelope{Version: 1, KDF: secretsKDF, Iterations: secretsIterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return err
+	}
+	gcm, err := secretsCipher(s.passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return err
+	}
+	env.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return err
+	}
+	env.Ciphertext = gcm.Seal(nil, env.Nonce, plain, []byte(secretsAAD))
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing secrets file: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// askPassphrase sets s.passphrase from FTI_PASSPHRASE or the terminal,
+// asking for confirmation when a new file is created.
+func (s *secretsFile) askPassphrase(confirm bool) error {
+	if s.passphrase != "" {
+		return nil
+	}
+	if v := os.Getenv("FTI_PASSPHRASE"); v != "" {
+		s.passphrase = v
+		return nil
+	}
+	p, err := readSecret("Secrets passphrase: ")
+	if err != nil {
+		return err
+	}
+	if p == "" {
+		return errors.New("empty passphrase")
+	}
+	if confirm {
+		again, err := readSecret("Repeat passphrase: ")
+		if err != nil {
+			return err
+		}
+		if again != p {
+			return errors.New("passphrases do not match")
+		}
+	}
+	s.passphrase = p
+	return nil
+}
+
+// readSecret prompts on stderr and reads a line from the terminal without
+// echoing it.
+func readSecret(label string) (string, error) {
+	restore, ok := disableEcho()
+	if !ok {
+		return "", errors.New("the secrets file needs a passphrase: set FTI_PASSPHRASE or run in a terminal")
+	}
+	fmt.Fprint(os.Stderr, label)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	restore()
+	fmt.Fprintln(os.Stderr)
+	if err != nil && line == "" {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func secretsCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSecretsFileRoundTrip(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_PASSPHRASE", "correct horse")
+
+	s, err := newSecretsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("default"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("Get before any Set = %v, want ErrSecretNotFound", err)
+	}
+	if err := s.Set("default", "ti_default_key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("staging", "ti_staging_key"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("secrets file mode = %o, want 600", perm)
+	}
+	data, _ := os.ReadFile(s.path)
+	if strings.Contains(string(data), "ti_default_key") || strings.Contains(string(data), "ti_staging_key") {
+		t.Fatal("secrets file holds a key in plain text")
+	}
+
+	// A fresh reader, as a later fti run would use.
+	s, _ = newSecretsFile()
+	for account, want := range map[string]string{"default": "ti_default_key", "staging": "ti_staging_key"} {
+		if got, err := s.Get(account); err != nil || got != want {
+			t.Errorf("Get(%q) = %q, %v; want %q", account, got, err, want)
+		}
+	}
+	if _, err := s.Get("prod"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Get(unknown) = %v, want ErrSecretNotFound", err)
+	}
+
+	t.Setenv("FTI_PASSPHRASE", "wrong")
+	s, _ = newSecretsFile()
+	if _, err := s.Get("default"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Get with the wrong passphrase = %v", err)
+	}
+}
+
+func TestSecretsFileTampered(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_PASSPHRASE", "correct horse")
+
+	s, _ := newSecretsFile()
+	if err := s.Set("default", "ti_default_key"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(s.path)
+	var env secretsEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	env.Ciphertext[0] ^= 1
+	data, _ = json.Marshal(env)
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ = newSecretsFile()
+	if _, err := s.Get("default"); err == nil || !strings.Contains(err.Error(), "damaged") {
+		t.Errorf("Get from a tampered file = %v", err)
+	}
+}
+
+func TestSecretsFileIterationsCap(t *testing.T) {
+	t.Setenv("FTI_HOME", t.TempDir())
+	t.Setenv("FTI_PASSPHRASE", "correct horse")
+
+	s, _ := newSecretsFile()
+	if err := s.Set("default", "ti_default_key"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(s.path)
+	var env secretsEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	env.Iterations = 1 << 40
+	data, _ = json.Marshal(env)
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ = newSecretsFile()
+	if _, err := s.Get("default"); err == nil || !strings.Contains(err.Error(), "not a valid fti secrets file") {
+		t.Errorf("Get with a huge work factor = %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrStreamUnsupported is returned by Stream when the API does not offer an
+// event stream for the endpoint, so callers can fall back to polling.
+var ErrStreamUnsupported = errors.New("event stream not supported by the API")
+
+const (
+	// defaultStreamRetry is the reconnect delay used until the server sends
+	// a "retry:" field.
+	defaultStreamRetry = time.Second
+	// streamIdleTimeout drops a connection that has sent nothing, not even
+	// a keep-alive comment, for this long.
+	streamIdleTimeout = 90 * time.Second
+)
+
+// Event is one server-sent event.
+type Event struct {
+	ID   string
+	Type string // "message" when the server names no event type
+	Data []byte
+}
+
+// StreamOptions controls Stream.
+type StreamOptions struct {
+	// LastEventID resumes the stream after this event.
+	LastEventID string
+	// OnConnect, if set, is called each time the stream is established,
+	// before any of its events.
+	OnConnect func()
+	// OnReconnect, if set, is called before each reconnect with the reason
+	// the connection ended and the wait before the next attempt.
+	OnReconnect func(err error, wait time.Duration)
+}
+
+// Stream opens a Server-Sent Events stream at path and calls fn for each
+// event until ctx is done or fn returns an error. Dropped connections are
+// re-established with Last-Event-ID so no events are missed; the stream
+// gives up after c.Retries consecutive attempts that deliver nothing.
+// Returns ErrStreamUnsupported if the API answers without an event stream.
+func (c *Client) Stream(ctx context.Context, path string, params url.Values, opts StreamOptions, fn func(Event) error) error {
+	// Offline mode has no network, and cassettes store whole bodies so they
+	// cannot hold an open-ended stream.
+	if _, ok := c.HTTPClient.Transport.(*Cassette); ok || c.Offline {
+		return ErrStreamUnsupported
+	}
+
+	target := path
+	if len(params) > 0 {
+		target += "?" + params.Encode()
+	}
+
+	s := &sseReader{lastID: opts.LastEventID, retry: defaultStreamRetry}
+	for failures := 0; ; {
+		base := c.streamBase(ctx)
+		endpoint := base + target
+		delivered, err := c.streamOnce(ctx, endpoint, s, opts.OnConnect, fn)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var rerr *retryableError
+		switch {
+		case errors.Is(err, errStreamEnded):
+		case errors.As(err, &rerr):
+			err = rerr.err
+			if c.Endpoints != nil && endpointFailed(err) {
+				c.Endpoints.fail(base)
+			}
+		default:
+			return err
+		}
+
+		wait := s.retry
+		if delivered {
+			failures = 0
+		} else {
+			if failures >= c.Retries {
+				return err
+			}
+			wait = max(wait, backoff(failures, c.RetryMaxWait))
+			failures++
+		}
+		if rerr != nil && rerr.retryAfter > 0 {
+			// As in retry, a Retry-After beyond RetryMaxWait ends the stream.
+			if rerr.retryAfter > c.RetryMaxWait {
+				return err
+			}
+			wait = rerr.retryAfter
+		}
+		c.Tracer.Notef("stream GET %s ended (%v), reconnecting in %s", endpoint, err, wait.Round(time.Millisecond))
+		if opts.OnReconnect != nil {
+			opts.OnReconnect(err, wait)
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// streamBase returns the base URL to open a stream on: the best of
+// c.Endpoints, or c.BaseURL.
+func (c *Client) streamBase(ctx context.Context) string {
+	if c.Endpoints == nil || len(c.Endpoints.URLs) < 2 {
+		return c.BaseURL
+	}
+	base := c.candidates(ctx)[0]
+	if base != c.BaseURL {
+		c.Tracer.Notef("using endpoint %s", base)
+	}
+	return base
+}
+
+var (
+	// errStreamEnded reports that the server closed an event stream cleanly.
+	errStreamEnded = errors.New("stream closed by server")
+	// errStreamIdle reports a connection dropped by the idle watchdog.
+	errStreamIdle = errors.New("stream timed out waiting for data")
+)
+
+// streamOnce runs a single stream connection. It reports whether any event
+// was delivered; transient failures come back as *retryableError.
+func (c *Client) streamOnce(ctx context.Context, endpoint string, s *sseReader, onConnect func(), fn func(Event) error) (bool, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	// The connection has no overall deadline: c.Timeout bounds the wait for
+	// response headers, and streamIdleTimeout any silence after that.
+	conn, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = streamIdleTimeout
+	}
+	watchdog := time.AfterFunc(timeout, func() { cancel(errStreamIdle) })
+	defer watchdog.Stop()
+
+	req, err := http.NewRequestWithContext(conn, "GET", endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	id := c.setClientHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastID != "" {
+		req.Header.Set("Last-Event-ID", s.lastID)
+	}
+
+	// netErr reports a connection failure, naming the watchdog if it fired.
+	netErr := func(path string, err error) error {
+		if cause := context.Cause(conn); errors.Is(cause, errStreamIdle) {
+			err = cause
+		}
+		return &retryableError{err: &NetworkError{Method: "GET", Endpoint: path, RequestID: id, Err: err}}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, netErr(req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	if c.Limiter != nil {
+		c.Limiter.Observe(resp.Header) //nolint:errcheck
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusNotImplemented:
+		return false, ErrStreamUnsupported
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := c.readBody(resp)
+		err := withRequestID(newAPIError(resp, body), id)
+		if isRetryableStatus(resp.StatusCode) {
+			return false, &retryableError{err: err, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+		}
+		return false, err
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "text/event-stream" {
+		return false, ErrStreamUnsupported
+	}
+	watchdog.Reset(streamIdleTimeout)
+	c.Tracer.Notef("stream GET %s connected (last event %q)", endpoint, s.lastID)
+	if onConnect != nil {
+		onConnect()
+	}
+
+	delivered := false
+	sc := bufio.NewScanner(resp.Body)
+	limit := c.MaxResponseSize
+	if limit <= 0 {
+		limit = DefaultMaxResponseSize
+	}
+	sc.Buffer(make([]byte, 0, 4096), int(limit))
+	for sc.Scan() {
+		watchdog.Reset(streamIdleTimeout)
+		ev, ok := s.line(sc.Bytes())
+		if !ok {
+			continue
+		}
+		if err := fn(ev); err != nil {
+			return delivered, err
+		}
+		delivered = true
+	}
+	if err := sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return delivered, &ResponseTooLargeError{Method: "GET", Endpoint: req.URL.Path, RequestID: id, Limit: limit}
+		}
+		return delivered, netErr(req.URL.Path, fmt.Errorf("reading stream: %w", err))
+	}
+	return delivered, errStreamEnded
+}
+
+// sseReader assembles events from text/event-stream lines. The last event
+// ID and retry delay outlive a single connection.
+type sseReader struct {
+	lastID string
+	retry  time.Duration
+
+	typ  string
+	data bytes.Buffer
+	has  bool // a data field was seen since the last dispatch
+}
+
+// line consumes one line and returns the event it completes, if any.
+func (s *sseReader) line(b []byte) (Event, bool) {
+	if len(b) == 0 {
+		if !s.has {
+			s.typ = ""
+			return Event{}, false
+		}
+		ev := Event{ID: s.lastID, Type: s.typ, Data: bytes.Clone(bytes.TrimSuffix(s.data.Bytes(), []byte("\n")))}
+		if ev.Type == "" {
+			ev.Type = "message"
+		}
+		s.typ, s.has = "", false
+		s.data.Reset()
+		return ev, true
+	}
+	if b[0] == ':' {
+		return Event{}, false // comment / keep-alive
+	}
+
+	field, value, _ := strings.Cut(string(b), ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "event":
+		s.typ = value
+	case "data":
+		s.data.WriteString(value)
+		s.data.WriteByte('\n')
+		s.has = true
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			s.lastID = value
+		}
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+			s.retry = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return Event{}, false
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamResumesAfterDrop(t *testing.T) {
+	var mu sync.Mutex
+	var lastIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		conn := len(lastIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if conn == 1 {
+			// Two events, then the connection drops.
+			fmt.Fprint(w, "retry: 10\n\n: keep-alive\n\nid: 1\nevent: trade\ndata: {\"n\":1}\n\nid: 2\ndata: line one\ndata: line two\n\n")
+			return
+		}
+		fmt.Fprint(w, "id: 3\nevent: trade\ndata: {\"n\":3}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	var events []Event
+	var connects, reconnects int
+	done := errors.New("done")
+	err := c.Stream(context.Background(), "/stream", nil, StreamOptions{
+		OnConnect:   func() { connects++ },
+		OnReconnect: func(err error, wait time.Duration) { reconnects++ },
+	}, func(ev Event) error {
+		events = append(events, ev)
+		if ev.ID == "3" {
+			return done
+		}
+		return nil
+	})
+	if !errors.Is(err, done) {
+		t.Fatalf("Stream = %v", err)
+	}
+
+	want := []Event{
+		{ID: "1", Type: "trade", Data: []byte(`{"n":1}`)},
+		{ID: "2", Type: "message", Data: []byte("line one\nline two")},
+		{ID: "3", Type: "trade", Data: []byte(`{"n":3}`)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, ev := range events {
+		if ev.ID != want[i].ID || ev.Type != want[i].Type || string(ev.Data) != string(want[i].Data) {
+			t.Errorf("event %d = %+v, want %+v", i, ev, want[i])
+		}
+	}
+	if connects != 2 || reconnects != 1 {
+		t.Errorf("connects = %d, reconnects = %d; want 2 and 1", connects, reconnects)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastIDs) != 2 || lastIDs[0] != "" || lastIDs[1] != "2" {
+		t.Errorf("Last-Event-ID per connection = %q, want [\"\" \"2\"]", lastIDs)
+	}
+}
+
+func TestStreamGivesUpAfterRetries(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	c.Retries = 2
+	c.RetryMaxWait = 10 * time.Millisecond
+	err := c.Stream(context.Background(), "/stream", nil, StreamOptions{}, func(Event) error { return nil })
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Stream = %v, want the 503", err)
+	}
+	if attempts != 3 {
+		t.Errorf("%d attempts, want 1 + 2 retries", attempts)
+	}
+}
+
+func TestStreamUnsupported(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	err := NewClient(srv.URL, "").Stream(context.Background(), "/stream", nil, StreamOptions{}, func(Event) error { return nil })
+	if !errors.Is(err, ErrStreamUnsupported) {
+		t.Fatalf("Stream = %v, want ErrStreamUnsupported so callers poll", err)
+	}
+}
+
+func TestStreamQuietAfterConnect(t *testing.T) {
+	// Headers arrive at once, the first event only after longer than
+	// Timeout: the connection must be judged by the idle timeout instead.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprint(w, "id: 1\ndata: late\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	c.Timeout = 100 * time.Millisecond
+	c.Retries = 0
+	done := errors.New("done")
+	err := c.Stream(context.Background(), "/stream", nil, StreamOptions{}, func(ev Event) error {
+		return done
+	})
+	if !errors.Is(err, done) {
+		t.Fatalf("Stream = %v, want the late event", err)
+	}
+}
+
+func TestStreamRetryAfterCapped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "")
+	c.RetryMaxWait = 50 * time.Millisecond
+	start := time.Now()
+	err := c.Stream(context.Background(), "/stream", nil, StreamOptions{}, func(Event) error { return nil })
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Stream = %v, want the 503", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %s on a Retry-After beyond RetryMaxWait", elapsed)
+	}
+}
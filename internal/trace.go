@@ -0,0 +1,269 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracer observes every HTTP attempt the client makes. With Log set it
+// prints a summary per request (--verbose); with HAR enabled it collects
+// entries for WriteHAR (--trace-file). Credentials are always redacted.
+type Tracer struct {
+	Log io.Writer
+
+	mu      sync.Mutex
+	har     bool
+	version string
+	entries []harEntry
+}
+
+// NewTracer returns a Tracer logging to log (nil for none) and, if har is
+// set, recording a HAR archive attributed to fti version.
+func NewTracer(log io.Writer, har bool, version string) *Tracer {
+	return &Tracer{Log: log, har: har, version: version}
+}
+
+// Notef logs a free-form line, e.g. a cache hit that made no request.
+func (t *Tracer) Notef(format string, args ...interface{}) {
+	if t == nil || t.Log == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Log, "%s %s\n", Dim.Sprint("*"), fmt.Sprintf(format, args...))
+}
+
+// record logs one round trip. resp is nil when err is set.
+func (t *Tracer) record(req *http.Request, reqBody []byte, resp *http.Response, body []byte, start time.Time, err error) {
+	if t == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	target := scrubKey(req.URL.String(), bearerToken(req))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Log != nil {
+		if err != nil {
+			fmt.Fprintf(t.Log, "%s %s %s %s %s\n", Dim.Sprint("→"), req.Method, target, Red.Sprint("error"), elapsed.Round(time.Millisecond))
+			fmt.Fprintf(t.Log, "  %s\n", Dim.Sprint(scrubKey(err.Error(), bearerToken(req))))
+		} else {
+			fmt.Fprintf(t.Log, "%s %s %s %s %s %s\n", Dim.Sprint("→"), req.Method, target,
+				statusColor(resp.StatusCode), elapsed.Round(time.Millisecond), formatSize(len(body)))
+		}
+		for _, h := range sortedHeaders(req.Header) {
+			fmt.Fprintf(t.Log, "  %s\n", Dim.Sprintf("> %s: %s", h.Name, h.Value))
+		}
+		if resp != nil {
+			if id := resp.Header.Get("X-Request-ID"); id != "" {
+				fmt.Fprintf(t.Log, "  %s\n", Dim.Sprintf("< X-Request-ID: %s", id))
+			}
+		}
+	}
+
+	if t.har {
+		t.entries = append(t.entries, newHAREntry(req, reqBody, resp, body, start, elapsed))
+	}
+}
+
+func statusColor(code int) string {
+	s := fmt.Sprintf("%d %s", code, http.StatusText(code))
+	switch {
+	case code >= 500:
+		return Red.Sprint(s)
+	case code >= 400:
+		return Yellow.Sprint(s)
+	default:
+		return Green.Sprint(s)
+	}
+}
+
+func formatSize(n int) string {
+	if n >= 1024 {
+		return fmt.Sprintf("%.1fKB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// redactHeader hides credentials while keeping enough to tell keys apart.
+func redactHeader(name, value string) string {
+	if !strings.EqualFold(name, "Authorization") {
+		return value
+	}
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok {
+		return "REDACTED"
+	}
+	return scheme + " " + MaskSecret(token)
+}
+
+// bearerToken returns the API key req is sent with, if any.
+func bearerToken(req *http.Request) string {
+	_, token, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	return token
+}
+
+// MaskSecret shows only the prefix of an API key, e.g. "ti_live_…REDACTED".
+func MaskSecret(s string) string {
+	if len(s) > 8 {
+		return s[:8] + "…REDACTED"
+	}
+	return "REDACTED"
+}
+
+// secretParams are query parameters that carry an API key.
+var secretParams = map[string]bool{"api_key": true, "apikey": true, "key": true}
+
+// ── HAR 1.2 ─────────────────────────────────────────────────────────────────
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func sortedHeaders(h http.Header) []harNameValue {
+	var out []harNameValue
+	for name, values := range h {
+		for _, v := range values {
+			out = append(out, harNameValue{Name: name, Value: redactHeader(name, v)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// newHAREntry describes one round trip. The API key is scrubbed from the
+// URL, query and bodies, e.g. the key returned by auth register.
+func newHAREntry(req *http.Request, reqBody []byte, resp *http.Response, body []byte, start time.Time, elapsed time.Duration) harEntry {
+	key := bearerToken(req)
+	ms := float64(elapsed.Microseconds()) / 1000
+	e := harEntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         scrubKey(req.URL.String(), key),
+			HTTPVersion: "HTTP/1.1",
+			Headers:     sortedHeaders(req.Header),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Timings: harTimings{Wait: ms},
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			if secretParams[strings.ToLower(name)] {
+				v = redacted
+			}
+			e.Request.QueryString = append(e.Request.QueryString, harNameValue{Name: name, Value: scrubKey(v, key)})
+		}
+	}
+	if len(reqBody) > 0 {
+		e.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: scrubKey(string(reqBody), key)}
+	}
+
+	// A failed request is recorded with status 0, as browsers do.
+	e.Response = harResponse{
+		HTTPVersion: "HTTP/1.1",
+		Headers:     []harNameValue{},
+		Cookies:     []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if resp != nil {
+		e.Response.Status = resp.StatusCode
+		e.Response.StatusText = http.StatusText(resp.StatusCode)
+		e.Response.HTTPVersion = resp.Proto
+		e.Response.Headers = sortedHeaders(resp.Header)
+		e.Response.BodySize = len(body)
+		e.Response.Content = harContent{Size: len(body), MimeType: resp.Header.Get("Content-Type"), Text: scrubKey(string(body), key)}
+	}
+	return e
+}
+
+// WriteHAR saves the recorded requests as a HAR 1.2 archive at path.
+func (t *Tracer) WriteHAR(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := t.entries
+	if entries == nil {
+		entries = []harEntry{}
+	}
+	doc := map[string]interface{}{
+		"log": map[string]interface{}{
+			"version": "1.2",
+			"creator": map[string]string{"name": "fti", "version": t.version},
+			"pages":   []struct{}{},
+			"entries": entries,
+		},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("writing trace file: %w", err)
+	}
+	return nil
+}
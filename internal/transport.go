@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportOptions configures how the client reaches the API on networks
+// with proxies, TLS interception or mutual TLS.
+type TransportOptions struct {
+	// Proxy is an http, https or socks5 URL. Empty uses HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY from the environment.
+	Proxy string
+	// CABundle is a PEM file of extra root certificates trusted in addition
+	// to the system pool, e.g. a corporate proxy's CA.
+	CABundle string
+	// ClientCert and ClientKey are PEM files presented for mutual TLS.
+	// Both or neither must be set.
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables server certificate verification. Only
+	// for local testing.
+	InsecureSkipVerify bool
+}
+
+// IsZero reports whether o leaves the default transport unchanged.
+func (o TransportOptions) IsZero() bool {
+	return o == TransportOptions{}
+}
+
+// NewTransport returns an http.Transport applying o on top of Go's defaults.
+func NewTransport(o TransportOptions) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if o.Proxy != "" {
+		u, err := ParseProxy(o.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	tlsCfg := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify} //nolint:gosec // opt-in for local testing
+	if o.CABundle != "" {
+		pool, _, err := LoadCABundle(o.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if o.ClientCert != "" || o.ClientKey != "" {
+		cert, err := LoadClientCert(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = tlsCfg
+	return t, nil
+}
+
+// ParseProxy validates a proxy URL.
+func ParseProxy(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("proxy: unsupported scheme in %q (want http, https or socks5)", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy: missing host in %q", raw)
+	}
+	return u, nil
+}
+
+// LoadCABundle returns the system roots plus the certificates in path, and
+// how many certificates path contributed.
+func LoadCABundle(path string) (*x509.CertPool, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ca bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	n := 0
+	for rest := data; len(rest) > 0; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("ca bundle %s: %w", path, err)
+		}
+		pool.AddCert(cert)
+		n++
+	}
+	if n == 0 {
+		return nil, 0, fmt.Errorf("ca bundle %s: no PEM certificates found", path)
+	}
+	return pool, n, nil
+}
+
+// LoadClientCert loads a PEM certificate and private key for mutual TLS.
+func LoadClientCert(certFile, keyFile string) (tls.Certificate, error) {
+	if certFile == "" || keyFile == "" {
+		return tls.Certificate{}, errors.New("client_cert and client_key must be set together")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("client certificate: %w", err)
+	}
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+	}
+	return cert, nil
+}
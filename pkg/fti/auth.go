@@ -0,0 +1,55 @@
+package fti
+
+import "context"
+
+// AgentInfo describes the API key the client authenticates with.
+type AgentInfo struct {
+	AgentID         string   `json:"agent_id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Tier            string   `json:"tier"`
+	Capabilities    []string `json:"capabilities"`
+	RateLimitPerMin int      `json:"rate_limit_per_minute"`
+	TotalRequests   int      `json:"total_requests"`
+	CreatedAt       string   `json:"created_at"`
+}
+
+// RegisterRequest is the payload for Register.
+type RegisterRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	Scope       string `json:"scope"` // read or full
+}
+
+// Registration is a newly issued API key.
+type Registration struct {
+	AgentID         string   `json:"agent_id"`
+	APIKey          string   `json:"api_key"`
+	Name            string   `json:"name"`
+	Tier            string   `json:"tier"`
+	RateLimitPerMin int      `json:"rate_limit_per_minute"`
+	EmailVerified   bool     `json:"email_verified"`
+	Capabilities    []string `json:"capabilities"`
+	Message         string   `json:"message"`
+}
+
+// Me returns information about the current API key.
+func (c *Client) Me(ctx context.Context) (*AgentInfo, *Response, error) {
+	var a AgentInfo
+	res, err := c.Get(ctx, "/api/v1/auth/me", nil, &a)
+	if err != nil {
+		return nil, res, err
+	}
+	return &a, res, nil
+}
+
+// Register issues a new API key. It needs no existing key.
+func (c *Client) Register(ctx context.Context, r RegisterRequest) (*Registration, *Response, error) {
+	var reg Registration
+	res, err := c.Post(ctx, "/api/v1/auth/register", r, &reg)
+	if err != nil {
+		return nil, res, err
+	}
+	return &reg, res, nil
+}
@@ -0,0 +1,126 @@
+// Package fti is a typed Go client for the Fan Token Intel API.
+//
+//	c := fti.NewClient(fti.DefaultBaseURL, os.Getenv("FTI_API_KEY"))
+//	tokens, _, err := c.ListTokens(ctx, nil)
+//
+// Every method returns the decoded value together with the raw *Response,
+// whose Body holds the exact JSON the API sent and whose Meta carries the
+// request ID to quote to the API operators.
+package fti
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+)
+
+// DefaultBaseURL is the production API.
+const DefaultBaseURL = "https://web-production-ad7c4.up.railway.app"
+
+// Client calls the Fan Token Intel API. It handles authentication,
+// timeouts and retries; Get and Post remain available for endpoints
+// without a typed wrapper.
+type Client struct {
+	c *internal.Client
+}
+
+// Response is the raw body and metadata of an API call.
+type Response = internal.Response
+
+// Meta describes where a response came from. Its stale fields are only set
+// by the fti command, which serves responses from a local cache; this
+// package always calls the live API.
+type Meta = internal.Meta
+
+// APIError is returned for non-2xx responses.
+type APIError = internal.APIError
+
+// NetworkError is returned when the API could not be reached.
+type NetworkError = internal.NetworkError
+
+// Option configures a Client in NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sends requests through hc, e.g. for a custom transport
+// or proxy. Its Timeout, if any, applies to each attempt.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.c.HTTPClient = hc }
+}
+
+// WithTimeout bounds each call, retries included. Zero means no limit.
+// The default is 30s.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.c.Timeout = d }
+}
+
+// WithRetries sets how many times a GET is retried after a network error,
+// 429 or 5xx. The default is 3.
+func WithRetries(n int) Option {
+	return func(c *Client) { c.c.Retries = max(n, 0) }
+}
+
+// WithRetryMaxWait caps the delay between retries. A longer Retry-After
+// ends the retries instead. The default is 30s.
+func WithRetryMaxWait(d time.Duration) Option {
+	return func(c *Client) { c.c.RetryMaxWait = d }
+}
+
+// WithUserAgent replaces the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.c.UserAgent = ua }
+}
+
+// WithMaxResponseSize rejects decoded responses larger than n bytes; 0
+// removes the limit. The default is 32MB.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) { c.c.MaxResponseSize = n }
+}
+
+// WithStrict makes responses that don't match the schema this package was
+// built for fail with an error instead of being decoded leniently.
+func WithStrict() Option {
+	return func(c *Client) { c.c.Strict = true }
+}
+
+// NewClient returns a client for baseURL authenticating with apiKey. The key
+// may be empty for public endpoints.
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{internal.NewClient(baseURL, apiKey)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WrapInternal returns a Client calling the API through c. It lets the fti
+// command, which configures its own cache, rate limiter and tracer, use
+// the typed methods; other modules cannot build c and should use NewClient.
+func WrapInternal(c *internal.Client) *Client {
+	return &Client{c}
+}
+
+// Get requests path with params and decodes the JSON body into out, if
+// non-nil.
+func (c *Client) Get(ctx context.Context, path string, params url.Values, out interface{}) (*Response, error) {
+	return c.c.Get(ctx, path, params, out)
+}
+
+// Post sends payload as JSON to path and decodes the reply into out, if
+// non-nil. It is not retried.
+func (c *Client) Post(ctx context.Context, path string, payload, out interface{}) (*Response, error) {
+	return c.c.Post(ctx, path, payload, out)
+}
+
+// query converts key/value pairs into url.Values, skipping empty values.
+func query(pairs ...string) url.Values {
+	q := url.Values{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			q.Set(pairs[i], pairs[i+1])
+		}
+	}
+	return q
+}
@@ -0,0 +1,45 @@
+package fti_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal/mockapi"
+	"github.com/BrunoPessoa22/fantokenintel-cli/pkg/fti"
+)
+
+func TestActiveSignalsCount(t *testing.T) {
+	srv := httptest.NewServer((&mockapi.Server{}).Handler())
+	defer srv.Close()
+
+	c := fti.NewClient(srv.URL, "ti_test_key",
+		fti.WithTimeout(5*time.Second),
+		fti.WithRetries(0),
+		fti.WithUserAgent("fti-test"),
+	)
+	list, res, err := c.ActiveSignals(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ActiveSignals == 0 || list.ActiveSignals != len(list.Signals) {
+		t.Errorf("active_signals = %d with %d signals", list.ActiveSignals, len(list.Signals))
+	}
+	if len(res.Body) == 0 {
+		t.Error("no raw body in the response")
+	}
+}
+
+func TestRawGet(t *testing.T) {
+	srv := httptest.NewServer((&mockapi.Server{}).Handler())
+	defer srv.Close()
+
+	var health map[string]interface{}
+	if _, err := fti.NewClient(srv.URL, "").Get(context.Background(), "/health", nil, &health); err != nil {
+		t.Fatal(err)
+	}
+	if len(health) == 0 {
+		t.Error("empty /health response")
+	}
+}
@@ -0,0 +1,106 @@
+package fti
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// DefaultPageSize is the page size used by the *Pages methods when the
+// options leave Limit unset.
+const DefaultPageSize = 100
+
+// pageInfo is the pagination envelope of paged endpoints. Cursor-paged
+// responses set NextCursor; offset-paged ones may set HasMore. When neither
+// is present, a short page marks the end.
+type pageInfo struct {
+	NextCursor string `json:"next_cursor"`
+	HasMore    *bool  `json:"has_more"`
+}
+
+// paginate fetches pages until the records run out or max (if positive)
+// records have been passed to fn. The final page is trimmed to max. A page
+// identical to the previous one means the server ignored the offset, and
+// also ends the loop.
+func paginate[T any](ctx context.Context, pageSize, max int,
+	fetch func(ctx context.Context, offset int, cursor string) ([]T, *Response, error),
+	fn func([]T, *Response) error) error {
+
+	offset, cursor, seen := 0, "", 0
+	var prev []byte
+	for {
+		page, res, err := fetch(ctx, offset, cursor)
+		if err != nil {
+			return err
+		}
+		sig, _ := json.Marshal(page)
+		if prev != nil && bytes.Equal(sig, prev) {
+			return nil
+		}
+		prev = sig
+
+		if max > 0 && seen+len(page) > max {
+			page = page[:max-seen]
+		}
+		if len(page) > 0 {
+			if err := fn(page, res); err != nil {
+				return err
+			}
+		}
+		seen += len(page)
+
+		var info pageInfo
+		json.Unmarshal(res.Body, &info) //nolint:errcheck // absent envelope means offset paging
+		switch {
+		case len(page) == 0, max > 0 && seen >= max:
+			return nil
+		case info.NextCursor != "":
+			if info.NextCursor == cursor {
+				return nil
+			}
+			cursor = info.NextCursor
+		case info.HasMore != nil && !*info.HasMore, info.HasMore == nil && len(page) < pageSize:
+			return nil
+		default:
+			offset += len(page)
+		}
+	}
+}
+
+// SignalHistoryPages calls fn with each page of SignalHistory, following
+// the API's pagination until the history is exhausted or maxRecords (if
+// positive) signals have been delivered. opts.Limit is the page size.
+func (c *Client) SignalHistoryPages(ctx context.Context, opts *SignalHistoryOptions, maxRecords int, fn func([]Signal, *Response) error) error {
+	o := SignalHistoryOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultPageSize
+	}
+	return paginate(ctx, o.Limit, maxRecords, func(ctx context.Context, offset int, cursor string) ([]Signal, *Response, error) {
+		o.Offset, o.Cursor = offset, cursor
+		return c.SignalHistory(ctx, &o)
+	}, fn)
+}
+
+// WhalePages calls fn with each page of whale trades, following the API's
+// pagination until the trades are exhausted or maxRecords (if positive)
+// trades have been delivered. opts.Limit is the page size.
+func (c *Client) WhalePages(ctx context.Context, opts *WhalesOptions, maxRecords int, fn func([]WhaleTrade, *Response) error) error {
+	o := WhalesOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultPageSize
+	}
+	return paginate(ctx, o.Limit, maxRecords, func(ctx context.Context, offset int, cursor string) ([]WhaleTrade, *Response, error) {
+		o.Offset, o.Cursor = offset, cursor
+		w, res, err := c.Whales(ctx, &o)
+		if err != nil {
+			return nil, res, err
+		}
+		return w.Transactions, res, nil
+	}, fn)
+}
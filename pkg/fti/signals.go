@@ -0,0 +1,93 @@
+package fti
+
+import (
+	"context"
+	"strconv"
+)
+
+// Signal is a trading signal. Outcome fields are only set on historical
+// signals; ExpiresAt, PrimaryReason and TrailingStopStatus only on active ones.
+type Signal struct {
+	ID                 string  `json:"id"`
+	Token              string  `json:"token"`
+	Direction          string  `json:"direction"`
+	Tier               string  `json:"tier"`
+	SellRatio          float64 `json:"sell_ratio"`
+	ConfidenceScore    float64 `json:"confidence_score"`
+	EntryPrice         float64 `json:"entry_price"`
+	TargetPrice        float64 `json:"target_price"`
+	StopPrice          float64 `json:"stop_price"`
+	CreatedAt          string  `json:"created_at"`
+	ExpiresAt          string  `json:"expires_at"`
+	PrimaryReason      string  `json:"primary_reason"`
+	MaxProfitPct       float64 `json:"max_profit_pct"`
+	TrailingStopStatus string  `json:"trailing_stop_status"`
+	OutcomeStatus      string  `json:"outcome_status"`
+	PnlPct             float64 `json:"pnl_pct"`
+	ExitTime           string  `json:"exit_time"`
+	ExitPrice          float64 `json:"exit_price"`
+}
+
+// ActiveSignalList is the set of open signals.
+type ActiveSignalList struct {
+	ActiveSignals int      `json:"active_signals"`
+	Signals       []Signal `json:"signals"`
+}
+
+// ActiveSignalsOptions controls ActiveSignals.
+type ActiveSignalsOptions struct {
+	Token         string
+	MinConfidence float64 // 0-1; 0 includes every signal
+}
+
+// SignalHistoryOptions controls SignalHistory.
+type SignalHistoryOptions struct {
+	Token   string
+	Days    int
+	Outcome string // target_hit, stopped_out or expired
+	Limit   int
+
+	// Offset or Cursor select a later page; see SignalHistoryPages.
+	Offset int
+	Cursor string
+}
+
+// ActiveSignals returns the currently open signals. Requires an API key.
+func (c *Client) ActiveSignals(ctx context.Context, opts *ActiveSignalsOptions) (*ActiveSignalList, *Response, error) {
+	if opts == nil {
+		opts = &ActiveSignalsOptions{}
+	}
+	q := query("token", opts.Token)
+	q.Set("min_confidence", strconv.FormatFloat(opts.MinConfidence, 'f', 2, 64))
+	var list ActiveSignalList
+	res, err := c.Get(ctx, "/api/v1/signals/active", q, &list)
+	if err != nil {
+		return nil, res, err
+	}
+	return &list, res, nil
+}
+
+// SignalHistory returns closed signals with their outcomes. Requires an API key.
+func (c *Client) SignalHistory(ctx context.Context, opts *SignalHistoryOptions) ([]Signal, *Response, error) {
+	if opts == nil {
+		opts = &SignalHistoryOptions{}
+	}
+	q := query("token", opts.Token, "outcome", opts.Outcome, "cursor", opts.Cursor)
+	if opts.Days > 0 {
+		q.Set("days", strconv.Itoa(opts.Days))
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		q.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	var resp struct {
+		Signals []Signal `json:"signals"`
+	}
+	res, err := c.Get(ctx, "/api/v1/signals/history", q, &resp)
+	if err != nil {
+		return nil, res, err
+	}
+	return resp.Signals, res, nil
+}
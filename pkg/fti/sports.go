@@ -0,0 +1,48 @@
+package fti
+
+import (
+	"context"
+	"strconv"
+)
+
+// Match is an upcoming fixture involving a fan token team.
+type Match struct {
+	MatchID         string  `json:"match_id"`
+	HomeTeam        string  `json:"home_team"`
+	AwayTeam        string  `json:"away_team"`
+	MatchDate       string  `json:"match_date"`
+	Competition     string  `json:"competition"`
+	Status          string  `json:"status"`
+	ImportanceScore float64 `json:"importance_score"`
+	HomeToken       string  `json:"home_token"`
+	AwayToken       string  `json:"away_token"`
+}
+
+// UpcomingMatchesOptions controls UpcomingMatches.
+type UpcomingMatchesOptions struct {
+	Token string
+	Days  int
+	Limit int
+}
+
+// UpcomingMatches returns matches in the next Days days.
+func (c *Client) UpcomingMatches(ctx context.Context, opts *UpcomingMatchesOptions) ([]Match, *Response, error) {
+	if opts == nil {
+		opts = &UpcomingMatchesOptions{}
+	}
+	q := query("token", opts.Token)
+	if opts.Days > 0 {
+		q.Set("days", strconv.Itoa(opts.Days))
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	var resp struct {
+		Matches []Match `json:"matches"`
+	}
+	res, err := c.Get(ctx, "/api/matches/upcoming", q, &resp)
+	if err != nil {
+		return nil, res, err
+	}
+	return resp.Matches, res, nil
+}
@@ -0,0 +1,109 @@
+package fti
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/BrunoPessoa22/fantokenintel-cli/internal"
+)
+
+// ErrStreamUnsupported is returned by the *Stream methods when the API does
+// not offer a push stream; poll Whales or ActiveSignals instead.
+var ErrStreamUnsupported = internal.ErrStreamUnsupported
+
+// Signal stream event types.
+const (
+	SignalOpened  = "signal.opened"
+	SignalUpdated = "signal.updated"
+	SignalClosed  = "signal.closed"
+)
+
+// WhaleEvent is a new whale trade pushed by the API.
+type WhaleEvent struct {
+	ID    string
+	Trade WhaleTrade
+}
+
+// SignalEvent is a change to an active signal pushed by the API. Type is
+// SignalOpened, SignalUpdated or SignalClosed.
+type SignalEvent struct {
+	ID     string
+	Type   string
+	Signal Signal
+}
+
+// WhaleStreamOptions controls WhaleStream. An empty Symbol covers all tokens.
+type WhaleStreamOptions struct {
+	Symbol   string
+	MinValue float64 // USD; 0 includes every trade
+
+	// LastEventID resumes after an event seen by an earlier stream.
+	LastEventID string
+	// OnConnect, if set, is called each time the stream is established.
+	OnConnect func()
+	// OnReconnect, if set, is called each time a dropped stream is retried.
+	OnReconnect func(err error, wait time.Duration)
+}
+
+// SignalStreamOptions controls SignalStream.
+type SignalStreamOptions struct {
+	Token         string
+	MinConfidence float64 // 0-1; 0 includes every signal
+
+	// LastEventID resumes after an event seen by an earlier stream.
+	LastEventID string
+	// OnConnect, if set, is called each time the stream is established.
+	OnConnect func()
+	// OnReconnect, if set, is called each time a dropped stream is retried.
+	OnReconnect func(err error, wait time.Duration)
+}
+
+// WhaleStream calls fn with each new whale trade as the API pushes it, until
+// ctx is done or fn returns an error. Dropped connections are resumed from
+// the last event. Returns ErrStreamUnsupported if the API has no stream.
+func (c *Client) WhaleStream(ctx context.Context, opts *WhaleStreamOptions, fn func(WhaleEvent) error) error {
+	if opts == nil {
+		opts = &WhaleStreamOptions{}
+	}
+	q := query("symbol", opts.Symbol)
+	q.Set("min_value", strconv.FormatFloat(opts.MinValue, 'f', 0, 64))
+	so := internal.StreamOptions{LastEventID: opts.LastEventID, OnConnect: opts.OnConnect, OnReconnect: opts.OnReconnect}
+	return c.c.Stream(ctx, "/api/v1/stream/whales", q, so, func(ev internal.Event) error {
+		if ev.Type != "whale" {
+			return nil
+		}
+		var tr WhaleTrade
+		if err := json.Unmarshal(ev.Data, &tr); err != nil {
+			return fmt.Errorf("parsing whale event %s: %w", ev.ID, err)
+		}
+		return fn(WhaleEvent{ID: ev.ID, Trade: tr})
+	})
+}
+
+// SignalStream calls fn for each signal opened, updated or closed, until
+// ctx is done or fn returns an error. Requires an API key. Dropped
+// connections are resumed from the last event. Returns ErrStreamUnsupported
+// if the API has no stream.
+func (c *Client) SignalStream(ctx context.Context, opts *SignalStreamOptions, fn func(SignalEvent) error) error {
+	if opts == nil {
+		opts = &SignalStreamOptions{}
+	}
+	q := query("token", opts.Token)
+	q.Set("min_confidence", strconv.FormatFloat(opts.MinConfidence, 'f', 2, 64))
+	so := internal.StreamOptions{LastEventID: opts.LastEventID, OnConnect: opts.OnConnect, OnReconnect: opts.OnReconnect}
+	return c.c.Stream(ctx, "/api/v1/stream/signals", q, so, func(ev internal.Event) error {
+		switch ev.Type {
+		case SignalOpened, SignalUpdated, SignalClosed:
+		default:
+			return nil
+		}
+		var s Signal
+		if err := json.Unmarshal(ev.Data, &s); err != nil {
+			return fmt.Errorf("parsing signal event %s: %w", ev.ID, err)
+		}
+		return fn(SignalEvent{ID: ev.ID, Type: ev.Type, Signal: s})
+	})
+}
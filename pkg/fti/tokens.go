@@ -0,0 +1,136 @@
+package fti
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Token is one row of the token list.
+type Token struct {
+	Symbol         string  `json:"symbol"`
+	Name           string  `json:"name"`
+	Team           string  `json:"team"`
+	Price          float64 `json:"price"`
+	PriceChange1h  float64 `json:"price_change_1h"`
+	PriceChange24h float64 `json:"price_change_24h"`
+	Volume24h      float64 `json:"volume_24h"`
+	MarketCap      float64 `json:"market_cap"`
+	HealthGrade    string  `json:"health_grade"`
+	HealthScore    float64 `json:"health_score"`
+}
+
+// TokenDetail is the full record for a single token.
+type TokenDetail struct {
+	Token     TokenInfo       `json:"token"`
+	Metrics   TokenMetrics    `json:"metrics"`
+	Exchanges []ExchangeQuote `json:"exchanges"`
+}
+
+// TokenInfo holds a token's static attributes.
+type TokenInfo struct {
+	ID                int    `json:"id"`
+	Symbol            string `json:"symbol"`
+	Name              string `json:"name"`
+	Team              string `json:"team"`
+	League            string `json:"league"`
+	Country           string `json:"country"`
+	TotalSupply       int64  `json:"total_supply"`
+	CirculatingSupply int64  `json:"circulating_supply"`
+	LaunchDate        string `json:"launch_date"`
+}
+
+// TokenMetrics holds a token's current market metrics.
+type TokenMetrics struct {
+	Price           float64 `json:"price"`
+	PriceChange1h   float64 `json:"price_change_1h"`
+	PriceChange24h  float64 `json:"price_change_24h"`
+	PriceChange7d   float64 `json:"price_change_7d"`
+	Volume24h       float64 `json:"volume_24h"`
+	MarketCap       float64 `json:"market_cap"`
+	TotalHolders    int     `json:"total_holders"`
+	HolderChange24h int     `json:"holder_change_24h"`
+	HealthScore     float64 `json:"health_score"`
+	HealthGrade     string  `json:"health_grade"`
+	Liquidity1pct   float64 `json:"liquidity_1pct"`
+	SpreadBps       float64 `json:"spread_bps"`
+}
+
+// ExchangeQuote is a token's market on one exchange.
+type ExchangeQuote struct {
+	Name      string  `json:"name"`
+	Price     float64 `json:"price"`
+	Volume24h float64 `json:"volume_24h"`
+	SpreadBps float64 `json:"spread_bps"`
+	BestBid   float64 `json:"best_bid"`
+	BestAsk   float64 `json:"best_ask"`
+}
+
+// PricePoint is one candle of price history.
+type PricePoint struct {
+	Time      string  `json:"time"`
+	Price     float64 `json:"price"`
+	Volume    float64 `json:"volume"`
+	Spread    float64 `json:"spread"`
+	Liquidity float64 `json:"liquidity"`
+}
+
+// PriceSeries is the price history of one token.
+type PriceSeries struct {
+	Symbol      string       `json:"symbol"`
+	PeriodHours int          `json:"period_hours"`
+	DataPoints  int          `json:"data_points"`
+	Prices      []PricePoint `json:"prices"`
+}
+
+// ListTokensOptions controls ListTokens. The zero value uses API defaults.
+type ListTokensOptions struct {
+	SortBy string // volume_24h, price_change_24h, market_cap or health_score
+	Order  string // asc or desc
+}
+
+// PriceHistoryOptions controls PriceHistory.
+type PriceHistoryOptions struct {
+	Interval string // 1h, 4h or 1d
+	Days     int
+}
+
+// ListTokens returns every tracked fan token with market metrics.
+func (c *Client) ListTokens(ctx context.Context, opts *ListTokensOptions) ([]Token, *Response, error) {
+	if opts == nil {
+		opts = &ListTokensOptions{}
+	}
+	var tokens []Token
+	res, err := c.Get(ctx, "/api/tokens", query("sort_by", opts.SortBy, "order", opts.Order), &tokens)
+	if err != nil {
+		return nil, res, err
+	}
+	return tokens, res, nil
+}
+
+// GetToken returns the detail record for symbol, e.g. "PSG".
+func (c *Client) GetToken(ctx context.Context, symbol string) (*TokenDetail, *Response, error) {
+	var t TokenDetail
+	res, err := c.Get(ctx, "/api/tokens/"+url.PathEscape(symbol), nil, &t)
+	if err != nil {
+		return nil, res, err
+	}
+	return &t, res, nil
+}
+
+// PriceHistory returns historical candles for symbol.
+func (c *Client) PriceHistory(ctx context.Context, symbol string, opts *PriceHistoryOptions) (*PriceSeries, *Response, error) {
+	if opts == nil {
+		opts = &PriceHistoryOptions{}
+	}
+	q := query("interval", opts.Interval)
+	if opts.Days > 0 {
+		q.Set("days", strconv.Itoa(opts.Days))
+	}
+	var s PriceSeries
+	res, err := c.Get(ctx, "/api/history/price/"+url.PathEscape(symbol), q, &s)
+	if err != nil {
+		return nil, res, err
+	}
+	return &s, res, nil
+}
@@ -0,0 +1,66 @@
+package fti
+
+import (
+	"context"
+	"strconv"
+)
+
+// WhaleTrade is a single large trade on a centralised or decentralised venue.
+type WhaleTrade struct {
+	Time         string  `json:"time"`
+	Venue        string  `json:"venue"`
+	Symbol       string  `json:"symbol"`
+	Exchange     string  `json:"exchange"`
+	Side         string  `json:"side"`
+	Price        float64 `json:"price"`
+	Quantity     float64 `json:"quantity"`
+	ValueUSD     float64 `json:"value_usd"`
+	IsAggressive bool    `json:"is_aggressive"`
+	TxHash       string  `json:"tx_hash"`
+}
+
+// WhaleActivity is the combined CEX + DEX whale feed.
+type WhaleActivity struct {
+	Transactions []WhaleTrade `json:"transactions"`
+	Count        int          `json:"count"`
+	CexCount     int          `json:"cex_count"`
+	DexCount     int          `json:"dex_count"`
+	ThresholdUSD float64      `json:"threshold_usd"`
+	Timestamp    string       `json:"timestamp"`
+}
+
+// WhalesOptions controls Whales. An empty Symbol covers all tokens.
+type WhalesOptions struct {
+	Symbol   string
+	Hours    int
+	Limit    int
+	MinValue float64 // USD; 0 includes every trade
+
+	// Offset or Cursor select a later page; see WhalePages.
+	Offset int
+	Cursor string
+}
+
+// Whales returns recent whale trades.
+func (c *Client) Whales(ctx context.Context, opts *WhalesOptions) (*WhaleActivity, *Response, error) {
+	if opts == nil {
+		opts = &WhalesOptions{}
+	}
+	q := query("symbol", opts.Symbol, "cursor", opts.Cursor)
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	q.Set("min_value", strconv.FormatFloat(opts.MinValue, 'f', 0, 64))
+	if opts.Hours > 0 {
+		q.Set("hours", strconv.Itoa(opts.Hours))
+	}
+	if opts.Offset > 0 {
+		q.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	var w WhaleActivity
+	res, err := c.Get(ctx, "/api/whales/combined", q, &w)
+	if err != nil {
+		return nil, res, err
+	}
+	return &w, res, nil
+}